@@ -157,6 +157,111 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForInterface(t *testing.T) {
 	}
 }
 
+func TestInterfaceFieldsWithArgumentsResolveOnConcreteTypes(t *testing.T) {
+	greetingArgs := graphql.FieldConfigArgument{
+		"greeting": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "Hello",
+		},
+	}
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"greet": &graphql.Field{
+				Type: graphql.String,
+				Args: greetingArgs,
+			},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*testDog)
+			return ok
+		},
+		Fields: graphql.Fields{
+			"greet": &graphql.Field{
+				Type: graphql.String,
+				Args: greetingArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Args["greeting"].(string) + ", dog " + p.Source.(*testDog).Name, nil
+				},
+			},
+		},
+	})
+	catType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: []*graphql.Interface{petType},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*testCat)
+			return ok
+		},
+		Fields: graphql.Fields{
+			"greet": &graphql.Field{
+				Type: graphql.String,
+				Args: greetingArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Args["greeting"].(string) + ", cat " + p.Source.(*testCat).Name, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{
+							&testDog{"Odie", true},
+							&testCat{"Garfield", false},
+						}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{catType, dogType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	query := `{
+      pets {
+        greet
+        welcome: greet(greeting: "Welcome")
+      }
+    }`
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{
+					"greet":   "Hello, dog Odie",
+					"welcome": "Welcome, dog Odie",
+				},
+				map[string]interface{}{
+					"greet":   "Hello, cat Garfield",
+					"welcome": "Welcome, cat Garfield",
+				},
+			},
+		},
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
 func TestIsTypeOfUsedToResolveRuntimeTypeForUnion(t *testing.T) {
 
 	dogType := graphql.NewObject(graphql.ObjectConfig{
@@ -372,6 +477,7 @@ func TestResolveTypeOnInterfaceYieldsUsefulError(t *testing.T) {
 				Type:      gqlerrors.ErrorTypeInternal,
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"pets", 2},
 			},
 		},
 	}
@@ -491,6 +597,7 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 				Type:      gqlerrors.ErrorTypeInternal,
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"pets", 2},
 			},
 		},
 	}
@@ -507,3 +614,275 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestResolveTypeNameResolvesRuntimeTypeByName(t *testing.T) {
+	typeName := func(p graphql.ResolveTypeParams) string {
+		switch p.Value.(type) {
+		case *testDog:
+			return "Dog"
+		case *testCat:
+			return "Cat"
+		}
+		return ""
+	}
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+		ResolveTypeName: typeName,
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"woofs": &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+	catType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: []*graphql.Interface{petType},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"meows": &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+	petUnion := graphql.NewUnion(graphql.UnionConfig{
+		Name:            "PetUnion",
+		Types:           []*graphql.Object{dogType, catType},
+		ResolveTypeName: typeName,
+	})
+	pets := []interface{}{&testDog{"Odie", true}, &testCat{"Garfield", false}}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return pets, nil
+					},
+				},
+				"petUnion": &graphql.Field{
+					Type: graphql.NewList(petUnion),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return pets, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{dogType, catType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	query := `{
+      pets { name ... on Dog { woofs } ... on Cat { meows } }
+      petUnion { ... on Dog { name } ... on Cat { meows } }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{"name": "Odie", "woofs": true},
+				map[string]interface{}{"name": "Garfield", "meows": false},
+			},
+			"petUnion": []interface{}{
+				map[string]interface{}{"name": "Odie"},
+				map[string]interface{}{"meows": false},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypenameResolvesToConcreteTypeOfAbstractFields(t *testing.T) {
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*testDog)
+			return ok
+		},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"woofs": &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+	catType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: []*graphql.Interface{petType},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*testCat)
+			return ok
+		},
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"meows": &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+	petUnion := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "PetUnion",
+		Types: []*graphql.Object{dogType, catType},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(*testDog); ok {
+				return dogType
+			}
+			return catType
+		},
+	})
+	ownerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Owner",
+		Fields: graphql.Fields{
+			"pet": &graphql.Field{
+				Type: petUnion,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+	pets := map[string]interface{}{
+		"dog": &testDog{"Odie", true},
+		"cat": &testCat{"Garfield", false},
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pet": &graphql.Field{
+					Type: petUnion,
+					Args: graphql.FieldConfigArgument{
+						"kind": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return pets[p.Args["kind"].(string)], nil
+					},
+				},
+				"owner": &graphql.Field{
+					Type: ownerType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return pets["cat"], nil
+					},
+				},
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{pets["dog"], pets["cat"]}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{dogType, catType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	query := `{
+      __typename
+      dog: pet(kind: "dog") { __typename ... on Dog { woofs } }
+      cat: pet(kind: "cat") { __typename ... on Pet { kind: __typename name } }
+      owner { __typename pet { __typename } }
+      pets { __typename name }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__typename": "Query",
+			"dog":        map[string]interface{}{"__typename": "Dog", "woofs": true},
+			"cat":        map[string]interface{}{"__typename": "Cat", "kind": "Cat", "name": "Garfield"},
+			"owner": map[string]interface{}{
+				"__typename": "Owner",
+				"pet":        map[string]interface{}{"__typename": "Cat"},
+			},
+			"pets": []interface{}{
+				map[string]interface{}{"__typename": "Dog", "name": "Odie"},
+				map[string]interface{}{"__typename": "Cat", "name": "Garfield"},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestUnresolvedAbstractTypeYieldsErrorWithPath(t *testing.T) {
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			_, ok := p.Value.(*testDog)
+			return ok
+		},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{&testDog{"Odie", true}, &testHuman{"Jon"}}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{dogType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ pets { name } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{"name": "Odie"},
+				nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Type:      gqlerrors.ErrorTypeInternal,
+				Message:   `Abstract type Pet must resolve to an Object type at runtime for field Query.pets; received *graphql_test.testHuman.`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []interface{}{"pets", 1},
+			},
+		},
+	}
+	if len(result.Errors) != 0 {
+		result.Errors[0].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
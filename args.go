@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// BindArgs copies the coerced field arguments into the struct pointed to by dst.
+// Arguments are matched to struct fields by name or by a `graphql` (or `json`) tag,
+// the same way the default resolver matches fields. Numeric values are converted to
+// the destination field's numeric type, which is an error if the value doesn't fit
+// (e.g. a fractional float for an int, or 300 for an int8), input objects are bound to nested structs
+// (or maps), and lists to slices. Arguments with no matching field are ignored.
+func (p ResolveParams) BindArgs(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("BindArgs requires a non-nil pointer to a struct, got %T", dst)
+	}
+	return bindValue(p.Args, v.Elem(), "args")
+}
+
+func bindValue(src interface{}, dst reflect.Value, path string) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := bindValue(src, elem.Elem(), path); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Struct:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			break
+		}
+		sm := fieldInfoForStruct(dst.Type())
+		for name, value := range m {
+			field, ok := sm[name]
+			if !ok {
+				continue
+			}
+			fv := dst.Field(field.index)
+			if !fv.CanSet() {
+				continue
+			}
+			if err := bindValue(value, fv, path+"."+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		m, ok := src.(map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			break
+		}
+		out := reflect.MakeMap(dst.Type())
+		for name, value := range m {
+			ev := reflect.New(dst.Type().Elem()).Elem()
+			if err := bindValue(value, ev, path+"."+name); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(name).Convert(dst.Type().Key()), ev)
+		}
+		dst.Set(out)
+		return nil
+	case reflect.Slice:
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			// Single values are accepted where a list is expected
+			out := reflect.MakeSlice(dst.Type(), 1, 1)
+			if err := bindValue(src, out.Index(0), path+"[0]"); err != nil {
+				return err
+			}
+			dst.Set(out)
+			return nil
+		}
+		out := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			if err := bindValue(sv.Index(i).Interface(), out.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if isNumericKind(sv.Kind()) {
+			if !numberFits(sv, dst) {
+				return fmt.Errorf("cannot bind %s value %v to %s without losing precision", path, src, dst.Type())
+			}
+			dst.Set(sv.Convert(dst.Type()))
+			return nil
+		}
+	case reflect.String, reflect.Bool:
+		if sv.Kind() == dst.Kind() {
+			dst.Set(sv.Convert(dst.Type()))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot bind %s of type %T to %s", path, src, dst.Type())
+}
+
+// numberFits reports whether the numeric value sv can be converted to the type
+// of dst without being truncated or overflowing. Integers may lose precision
+// when they're converted to floats, as they do when coerced to Float.
+func numberFits(sv, dst reflect.Value) bool {
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return !dst.OverflowInt(sv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return sv.Uint() <= math.MaxInt64 && !dst.OverflowInt(int64(sv.Uint()))
+		default:
+			f := sv.Float()
+			return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !dst.OverflowInt(int64(f))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return sv.Int() >= 0 && !dst.OverflowUint(uint64(sv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return !dst.OverflowUint(sv.Uint())
+		default:
+			f := sv.Float()
+			return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !dst.OverflowUint(uint64(f))
+		}
+	case reflect.Float32:
+		switch sv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return !dst.OverflowFloat(sv.Float())
+		}
+	}
+	return true
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type bindColor int
+
+const (
+	bindRed bindColor = iota
+	bindGreen
+)
+
+type bindPointInput struct {
+	X float64 `graphql:"x"`
+	Y float64 `graphql:"y"`
+}
+
+type bindArgs struct {
+	Name   string          `graphql:"name"`
+	Count  int32           `graphql:"count"`
+	Ratio  float32         `graphql:"ratio"`
+	Ready  *bool           `graphql:"ready"`
+	Color  bindColor       `graphql:"color"`
+	Origin *bindPointInput `graphql:"origin"`
+	Path   []bindPointInput
+	Tags   []string `json:"tags"`
+}
+
+func TestResolveParams_BindArgs(t *testing.T) {
+	colorEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: bindRed},
+			"GREEN": &graphql.EnumValueConfig{Value: bindGreen},
+		},
+	})
+	pointInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Point",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"x": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"y": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+		},
+	})
+
+	var bound bindArgs
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"name":   &graphql.ArgumentConfig{Type: graphql.String},
+			"count":  &graphql.ArgumentConfig{Type: graphql.Int},
+			"ratio":  &graphql.ArgumentConfig{Type: graphql.Float},
+			"ready":  &graphql.ArgumentConfig{Type: graphql.Boolean},
+			"color":  &graphql.ArgumentConfig{Type: colorEnum},
+			"origin": &graphql.ArgumentConfig{Type: pointInput},
+			"Path":   &graphql.ArgumentConfig{Type: graphql.NewList(pointInput)},
+			"tags":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			bound = bindArgs{}
+			if err := p.BindArgs(&bound); err != nil {
+				return nil, err
+			}
+			return "ok", nil
+		},
+	})
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{ test(
+			name: "abc", count: 3, ratio: 0.5, ready: true, color: GREEN,
+			origin: {x: 1, y: 2.5}, Path: [{x: 3}, {y: 4}], tags: "one"
+		) }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+
+	ready := true
+	expected := bindArgs{
+		Name:   "abc",
+		Count:  3,
+		Ratio:  0.5,
+		Ready:  &ready,
+		Color:  bindGreen,
+		Origin: &bindPointInput{X: 1, Y: 2.5},
+		Path:   []bindPointInput{{X: 3}, {Y: 4}},
+		Tags:   []string{"one"},
+	}
+	if !reflect.DeepEqual(expected, bound) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, bound))
+	}
+}
+
+func TestResolveParams_BindArgs_RejectsMismatchedTypes(t *testing.T) {
+	p := graphql.ResolveParams{
+		Args: map[string]interface{}{
+			"name": 123,
+		},
+	}
+	var dst struct {
+		Name string `graphql:"name"`
+	}
+	if err := p.BindArgs(&dst); err == nil {
+		t.Fatal("Expected an error binding an int to a string")
+	}
+	if err := p.BindArgs(dst); err == nil {
+		t.Fatal("Expected an error binding to a non-pointer")
+	}
+}
+
+func TestResolveParams_BindArgs_RejectsLossyNumbers(t *testing.T) {
+	var dst struct {
+		Small int8    `graphql:"small"`
+		Count uint    `graphql:"count"`
+		Whole int     `graphql:"whole"`
+		Ratio float32 `graphql:"ratio"`
+	}
+	for _, args := range []map[string]interface{}{
+		{"small": 300},
+		{"count": -1},
+		{"whole": 1.5},
+		{"whole": 1e30},
+		{"ratio": 1e300},
+	} {
+		p := graphql.ResolveParams{Args: args}
+		if err := p.BindArgs(&dst); err == nil {
+			t.Errorf("Expected an error binding %v", args)
+		}
+	}
+
+	p := graphql.ResolveParams{
+		Args: map[string]interface{}{"small": 100, "count": 7, "whole": 2.0, "ratio": 0.5},
+	}
+	if err := p.BindArgs(&dst); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dst.Small != 100 || dst.Count != 7 || dst.Whole != 2 || dst.Ratio != 0.5 {
+		t.Fatalf("Unexpected bound values %+v", dst)
+	}
+}
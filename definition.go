@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -235,6 +236,9 @@ type Scalar struct {
 
 	scalarConfig ScalarConfig
 	err          error
+	// serializeErrors is set for built-in scalars, for which values that
+	// fail to serialize are reported as field errors.
+	serializeErrors bool
 }
 
 // SerializeFn is a function type for serializing a GraphQLScalar type value
@@ -253,6 +257,9 @@ type ScalarConfig struct {
 	Serialize    SerializeFn
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
+	// SpecifiedByURL is the URL of the scalar's specification, as declared
+	// with the @specifiedBy directive.
+	SpecifiedByURL string `json:"specifiedByURL"`
 }
 
 // NewScalar creates a new GraphQLScalar
@@ -312,6 +319,10 @@ func (st *Scalar) Description() string {
 	return st.PrivateDescription
 
 }
+// SpecifiedByURL returns the URL of the scalar's specification, if any.
+func (st *Scalar) SpecifiedByURL() string {
+	return st.scalarConfig.SpecifiedByURL
+}
 func (st *Scalar) String() string {
 	return st.PrivateName
 }
@@ -506,7 +517,7 @@ func (gt *Object) Error() error {
 	return gt.err.Load().(errWrapper).err
 }
 
-func defineInterfaces(ttype *Object, interfaces []*Interface) ([]*Interface, error) {
+func defineInterfaces(ttype Named, interfaces []*Interface) ([]*Interface, error) {
 	if len(interfaces) == 0 {
 		return nil, nil
 	}
@@ -552,6 +563,7 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 			Description:       field.Description,
 			Type:              field.Type,
 			Resolve:           field.Resolve,
+			Complexity:        field.Complexity,
 			DeprecationReason: field.DeprecationReason,
 		}
 
@@ -576,6 +588,11 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 				}
 				fieldDef.Args = append(fieldDef.Args, fieldArg)
 			}
+			// Arguments are configured with a map so they're sorted by name to
+			// be listed in a stable order.
+			sort.Slice(fieldDef.Args, func(i, j int) bool {
+				return fieldDef.Args[i].PrivateName < fieldDef.Args[j].PrivateName
+			})
 		}
 		resultFieldMap[fieldName] = fieldDef
 	}
@@ -587,7 +604,10 @@ type ResolveParams struct {
 	// Source is the source value
 	Source interface{}
 
-	// Args is a map of arguments for current GraphQL request
+	// Args is a map of arguments for current GraphQL request. Each call gets
+	// its own map and its own copy of list and input object literals. Values
+	// given as variables are shared with Info.VariableValues, so they must
+	// not be modified.
 	Args map[string]interface{}
 
 	// Info is a collection of information about the current execution state.
@@ -611,6 +631,25 @@ type ResolveInfo struct {
 	RootValue      interface{}
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
+
+	// OperationName is the name of the executing operation. It's empty for
+	// anonymous operations.
+	OperationName string
+
+	// RawQuery is the source text of the executing document when available
+	// (i.e. the document was parsed with its source retained).
+	RawQuery string
+
+	// Path is the response path to the field being resolved made up of
+	// response names and list indices (e.g. ["hero", "friends", 0, "name"]).
+	Path []interface{}
+
+	// ResolvedSiblings holds the completed values, by response name, of the
+	// fields on the same object that were resolved before this one, in the
+	// order they're selected. It's only set for serially resolved selection
+	// sets (i.e. the root fields of a mutation), and is nil otherwise. It's a
+	// snapshot taken for this field, so modifying it doesn't affect the result.
+	ResolvedSiblings map[string]interface{}
 }
 
 type Fields map[string]*Field
@@ -620,10 +659,16 @@ type Field struct {
 	Type              Output              `json:"type"`
 	Args              FieldConfigArgument `json:"args"`
 	Resolve           FieldResolveFn
+	Complexity        ComplexityFn
 	DeprecationReason string `json:"deprecationReason"`
 	Description       string `json:"description"`
 }
 
+// ComplexityFn computes the complexity of a field given the complexity of its
+// selection set and its arguments. It's used by MaxComplexityRule and allows
+// e.g. list fields to multiply the child complexity by a `first` argument.
+type ComplexityFn func(childComplexity int, args map[string]interface{}) int
+
 type FieldConfigArgument map[string]*ArgumentConfig
 
 type ArgumentConfig struct {
@@ -639,6 +684,7 @@ type FieldDefinition struct {
 	Type              Output         `json:"type"`
 	Args              []*Argument    `json:"args"`
 	Resolve           FieldResolveFn `json:"-"`
+	Complexity        ComplexityFn   `json:"-"`
 	DeprecationReason string         `json:"deprecationReason"`
 }
 
@@ -691,20 +737,27 @@ type Interface struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
+	ResolveTypeName    ResolveTypeNameFn
 
 	mu         sync.RWMutex
 	typeConfig InterfaceConfig
 	fields     FieldDefinitionMap
+	interfaces []*Interface
 
 	muErr sync.RWMutex
 	err   error
 }
 
 type InterfaceConfig struct {
-	Name        string      `json:"name"`
-	Fields      interface{} `json:"fields"`
-	ResolveType ResolveTypeFn
-	Description string `json:"description"`
+	Name string `json:"name"`
+	// Interfaces are the interfaces the interface implements, given as a
+	// []*Interface or an InterfacesThunk. Like objects, it must also list the
+	// interfaces those interfaces implement.
+	Interfaces      interface{} `json:"interfaces"`
+	Fields          interface{} `json:"fields"`
+	ResolveType     ResolveTypeFn
+	ResolveTypeName ResolveTypeNameFn
+	Description     string `json:"description"`
 }
 
 // ResolveTypeParams Params for ResolveTypeFn()
@@ -724,11 +777,17 @@ type ResolveTypeParams struct {
 
 type ResolveTypeFn func(p ResolveTypeParams) *Object
 
+// ResolveTypeNameFn is an alternative to ResolveTypeFn that returns the name of
+// the concrete Object type, which the executor then looks up in the schema. It's
+// used when ResolveType is nil.
+type ResolveTypeNameFn func(p ResolveTypeParams) string
+
 func NewInterface(config InterfaceConfig) *Interface {
 	it := &Interface{
 		PrivateName:        config.Name,
 		PrivateDescription: config.Description,
 		ResolveType:        config.ResolveType,
+		ResolveTypeName:    config.ResolveTypeName,
 		typeConfig:         config,
 	}
 	if config.Name == "" {
@@ -789,6 +848,44 @@ func (it *Interface) Fields() FieldDefinitionMap {
 	return it.fields
 }
 
+// Interfaces returns the interfaces the interface implements.
+func (it *Interface) Interfaces() []*Interface {
+	it.mu.RLock()
+	interfaces := it.interfaces
+	it.mu.RUnlock()
+	if interfaces != nil {
+		return interfaces
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.interfaces != nil {
+		return it.interfaces
+	}
+
+	var configInterfaces []*Interface
+	switch it.typeConfig.Interfaces.(type) {
+	case InterfacesThunk:
+		configInterfaces = it.typeConfig.Interfaces.(InterfacesThunk)()
+	case []*Interface:
+		configInterfaces = it.typeConfig.Interfaces.([]*Interface)
+	case nil:
+	default:
+		it.muErr.Lock()
+		it.err = fmt.Errorf("Unknown Interface.Interfaces type: %v", reflect.TypeOf(it.typeConfig.Interfaces))
+		it.muErr.Unlock()
+		return nil
+	}
+	interfaces, err := defineInterfaces(it, configInterfaces)
+	if err != nil {
+		it.muErr.Lock()
+		it.err = err
+		it.muErr.Unlock()
+	}
+	it.interfaces = interfaces
+	return it.interfaces
+}
+
 func (it *Interface) String() string {
 	return it.PrivateName
 }
@@ -822,6 +919,7 @@ type Union struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
+	ResolveTypeName    ResolveTypeNameFn
 
 	typeConfig UnionConfig
 	types      []*Object
@@ -829,10 +927,11 @@ type Union struct {
 	err error
 }
 type UnionConfig struct {
-	Name        string    `json:"name"`
-	Types       []*Object `json:"types"`
-	ResolveType ResolveTypeFn
-	Description string `json:"description"`
+	Name            string    `json:"name"`
+	Types           []*Object `json:"types"`
+	ResolveType     ResolveTypeFn
+	ResolveTypeName ResolveTypeNameFn
+	Description     string `json:"description"`
 }
 
 func NewUnion(config UnionConfig) *Union {
@@ -840,6 +939,7 @@ func NewUnion(config UnionConfig) *Union {
 		PrivateName:        config.Name,
 		PrivateDescription: config.Description,
 		ResolveType:        config.ResolveType,
+		ResolveTypeName:    config.ResolveTypeName,
 	}
 	if config.Name == "" {
 		objectType.err = gqlerrors.NewFormattedError("Type must be named.")
@@ -859,7 +959,7 @@ func NewUnion(config UnionConfig) *Union {
 			objectType.err = gqlerrors.NewFormattedError(fmt.Sprintf(`%v may only contain Object types, it cannot contain: %v.`, objectType, ttype))
 			return objectType
 		}
-		if objectType.ResolveType == nil {
+		if objectType.ResolveType == nil && objectType.ResolveTypeName == nil {
 			if ttype.IsTypeOf == nil {
 				objectType.err = gqlerrors.NewFormattedError(fmt.Sprintf(`Union Type %v does not provide a "resolveType" function `+
 					`and possible Type %v does not provide a "isTypeOf" `+
@@ -932,6 +1032,13 @@ type EnumConfig struct {
 	Name        string             `json:"name"`
 	Values      EnumValueConfigMap `json:"values"`
 	Description string             `json:"description"`
+
+	// WarnDeprecatedInput reports deprecated values that are used in arguments
+	// or variables as warnings in the "warnings" extension of the result.
+	WarnDeprecatedInput bool `json:"-"`
+	// StrictDeprecatedInput rejects arguments and variables that use
+	// deprecated values with an error instead of warning about them.
+	StrictDeprecatedInput bool `json:"-"`
 }
 type EnumValueDefinition struct {
 	Name              string      `json:"name"`
@@ -985,6 +1092,11 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 		}
 		values = append(values, value)
 	}
+	// Values are configured with a map so they're sorted by name to be listed in
+	// a stable order.
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
 	return values, nil
 }
 func (gt *Enum) Values() []*EnumValueDefinition {
@@ -994,6 +1106,13 @@ func (gt *Enum) Serialize(value interface{}) interface{} {
 	if enumValue, ok := gt.getValueLookup()[value]; ok {
 		return enumValue.Name
 	}
+	// Fall back to matching a value by name for types that describe
+	// themselves as a string (e.g. generated enum types).
+	if s, ok := value.(fmt.Stringer); ok {
+		if enumValue, ok := gt.getNameLookup()[s.String()]; ok {
+			return enumValue.Name
+		}
+	}
 	return nil
 }
 func (gt *Enum) ParseValue(value interface{}) interface{} {
@@ -1014,6 +1133,18 @@ func (gt *Enum) ParseLiteral(valueAST ast.Value) interface{} {
 	}
 	return nil
 }
+
+// deprecatedInput returns the definition of the named value if it's deprecated
+// and the enum reports deprecated values that are used as input.
+func (gt *Enum) deprecatedInput(name string) *EnumValueDefinition {
+	if !gt.enumConfig.WarnDeprecatedInput && !gt.enumConfig.StrictDeprecatedInput {
+		return nil
+	}
+	if value, ok := gt.getNameLookup()[name]; ok && value.DeprecationReason != "" {
+		return value
+	}
+	return nil
+}
 func (gt *Enum) Name() string {
 	return gt.PrivateName
 }
@@ -1133,6 +1264,9 @@ type InputObjectConfig struct {
 	Name        string      `json:"name"`
 	Fields      interface{} `json:"fields"`
 	Description string      `json:"description"`
+	// IsOneOf marks the input object as a @oneOf input object, for which exactly
+	// one field must be given a non-null value.
+	IsOneOf bool `json:"isOneOf"`
 }
 
 func NewInputObject(config InputObjectConfig) *InputObject {
@@ -1144,6 +1278,11 @@ func NewInputObject(config InputObjectConfig) *InputObject {
 	gt.PrivateName = config.Name
 	gt.PrivateDescription = config.Description
 	gt.typeConfig = config
+	// A thunk is evaluated lazily by Fields so that input objects may refer to
+	// each other (or to themselves).
+	if _, ok := config.Fields.(InputObjectConfigFieldMapThunk); ok {
+		return gt
+	}
 	gt.mu.Lock()
 	defer gt.mu.Unlock()
 	gt.fields = gt.defineFieldMap()
@@ -1217,6 +1356,12 @@ func (gt *InputObject) Name() string {
 func (gt *InputObject) Description() string {
 	return gt.PrivateDescription
 }
+
+// IsOneOf returns true if exactly one field of the input object must be given a
+// non-null value.
+func (gt *InputObject) IsOneOf() bool {
+	return gt.typeConfig.IsOneOf
+}
 func (gt *InputObject) String() string {
 	return gt.PrivateName
 }
@@ -1,6 +1,11 @@
 package graphql
 
-import "github.com/sprucehealth/graphql/gqlerrors"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
 
 const (
 	// Operations
@@ -26,6 +31,27 @@ const (
 	DirectiveLocationInputFieldDefinition = "INPUT_FIELD_DEFINITION"
 )
 
+var directiveLocations = map[string]struct{}{
+	DirectiveLocationQuery:                {},
+	DirectiveLocationMutation:             {},
+	DirectiveLocationSubscription:         {},
+	DirectiveLocationField:                {},
+	DirectiveLocationFragmentDefinition:   {},
+	DirectiveLocationFragmentSpread:       {},
+	DirectiveLocationInlineFragment:       {},
+	DirectiveLocationSchema:               {},
+	DirectiveLocationScalar:               {},
+	DirectiveLocationObject:               {},
+	DirectiveLocationFieldDefinition:      {},
+	DirectiveLocationArgumentDefinition:   {},
+	DirectiveLocationInterface:            {},
+	DirectiveLocationUnion:                {},
+	DirectiveLocationEnum:                 {},
+	DirectiveLocationEnumValue:            {},
+	DirectiveLocationInputObject:          {},
+	DirectiveLocationInputFieldDefinition: {},
+}
+
 // DefaultDeprecationReason Constant string used for default reason for a deprecation.
 const DefaultDeprecationReason = "No longer supported"
 
@@ -34,6 +60,7 @@ var SpecifiedDirectives = []*Directive{
 	IncludeDirective,
 	SkipDirective,
 	DeprecatedDirective,
+	SpecifiedByDirective,
 }
 
 // Directive structs are used by the GraphQL runtime as a way of modifying execution
@@ -76,6 +103,12 @@ func NewDirective(config DirectiveConfig) *Directive {
 		dir.err = gqlerrors.NewFormattedError("Must provide locations for directive.")
 		return dir
 	}
+	for _, loc := range config.Locations {
+		if _, ok := directiveLocations[loc]; !ok {
+			dir.err = gqlerrors.NewFormattedError(fmt.Sprintf(`Directive "%s" has unknown location "%s".`, config.Name, loc))
+			return dir
+		}
+	}
 
 	args := []*Argument{}
 
@@ -93,6 +126,10 @@ func NewDirective(config DirectiveConfig) *Directive {
 		})
 	}
 
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].PrivateName < args[j].PrivateName
+	})
+
 	dir.Name = config.Name
 	dir.Description = config.Description
 	dir.Locations = config.Locations
@@ -154,3 +191,18 @@ var DeprecatedDirective = NewDirective(DirectiveConfig{
 		DirectiveLocationEnumValue,
 	},
 })
+
+// SpecifiedByDirective is used to link a custom scalar to its specification.
+var SpecifiedByDirective = NewDirective(DirectiveConfig{
+	Name:        "specifiedBy",
+	Description: "Exposes a URL that specifies the behaviour of this scalar.",
+	Args: FieldConfigArgument{
+		"url": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The URL that specifies the behaviour of this scalar.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationScalar,
+	},
+})
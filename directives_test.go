@@ -162,6 +162,84 @@ func TestDirectives_DirectiveArgNamesMustBeValid(t *testing.T) {
 	}
 }
 
+func TestDirectives_DirectiveLocationsMustBeKnown(t *testing.T) {
+	invalidDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name: "cached",
+		Locations: []string{
+			graphql.DirectiveLocationField,
+			"FIELD_DEF",
+		},
+	})
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "TestType",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{invalidDirective},
+	})
+	expectedErr := gqlerrors.FormattedError{
+		Message:       `Directive "cached" has unknown location "FIELD_DEF".`,
+		Locations:     []location.SourceLocation{},
+		Type:          "INTERNAL",
+		OriginalError: errors.New(`Directive "cached" has unknown location "FIELD_DEF".`),
+	}
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
+	}
+}
+
+func TestDirectives_RejectsDirectivesUsedOutsideTheirLocations(t *testing.T) {
+	onFragment := graphql.NewDirective(graphql.DirectiveConfig{
+		Name: "onFragment",
+		Locations: []string{
+			graphql.DirectiveLocationFragmentSpread,
+			graphql.DirectiveLocationInlineFragment,
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "TestType",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{onFragment}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query Q @skip(if: true) {
+  a @onFragment
+  ... @onFragment { a }
+}`,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `Directive "skip" may not be used on QUERY.`,
+				Type:      gqlerrors.ErrorTypeBadQuery,
+				Locations: []location.SourceLocation{{Line: 1, Column: 9}},
+			},
+			{
+				Message:   `Directive "onFragment" may not be used on FIELD.`,
+				Type:      gqlerrors.ErrorTypeBadQuery,
+				Locations: []location.SourceLocation{{Line: 2, Column: 5}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
 func TestDirectivesWorksWithoutDirectives(t *testing.T) {
 	query := `{ a, b }`
 	expected := &graphql.Result{
@@ -595,3 +673,165 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_NoIncludeOrSkip(t *testing.
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestDirectivesSkippedFragmentsDoNotRemoveOverlappingFields(t *testing.T) {
+	query := `
+        query Q($skip: Boolean!, $include: Boolean!) {
+          ... on TestType @skip(if: $skip) {
+            a
+            b
+          }
+          a
+          ...Frag @include(if: $include)
+        }
+        fragment Frag on TestType {
+          a
+          b
+        }
+	`
+	ast := testutil.TestParse(t, query)
+	for _, tc := range []struct {
+		skip, include bool
+		expected      map[string]interface{}
+	}{
+		{skip: true, include: false, expected: map[string]interface{}{"a": "a"}},
+		{skip: true, include: true, expected: map[string]interface{}{"a": "a", "b": "b"}},
+		{skip: false, include: false, expected: map[string]interface{}{"a": "a", "b": "b"}},
+	} {
+		result := testutil.TestExecute(t, graphql.ExecuteParams{
+			Schema: directivesTestSchema,
+			AST:    ast,
+			Root:   directivesTestData,
+			Args: map[string]interface{}{
+				"skip":    tc.skip,
+				"include": tc.include,
+			},
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		expected := &graphql.Result{Data: tc.expected}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result for skip=%t include=%t, Diff: %v", tc.skip, tc.include, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestDirectivesAppliedPerOccurrenceAcrossFragments(t *testing.T) {
+	query := `
+        query Q($skip: Boolean!) {
+          ...SkipsB
+          ...IncludesB
+        }
+        fragment SkipsB on TestType {
+          a
+          b @skip(if: $skip)
+        }
+        fragment IncludesB on TestType {
+          b @skip(if: false)
+        }
+	`
+	result := graphql.Do(graphql.Params{
+		Schema:         directivesTestSchema,
+		RequestString:  query,
+		RootObject:     directivesTestData,
+		VariableValues: map[string]interface{}{"skip": true},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "a",
+			"b": "b",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectives_CustomDirectiveRestrictedToFieldDefinitions(t *testing.T) {
+	authDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:        "auth",
+		Description: "Restricts a field to users with the given role.",
+		Locations:   []string{graphql.DirectiveLocationFieldDefinition},
+		Args: graphql.FieldConfigArgument{
+			"role": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "TestType",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+				},
+			},
+		}),
+		Directives: append([]*graphql.Directive{authDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// Allowed on field definitions in the schema language
+	testutil.ExpectPassesRuleWithSchema(t, &schema, graphql.KnownDirectivesRule, `
+      type Secret {
+        value: String @auth(role: "admin")
+      }
+    `)
+
+	// Rejected when used on a field in a query
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a @auth(role: "admin") }`,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `Directive "auth" may not be used on FIELD.`,
+				Type:      gqlerrors.ErrorTypeBadQuery,
+				Locations: []location.SourceLocation{{Line: 1, Column: 5}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Exposed through introspection
+	result = graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			__schema {
+				directives {
+					name
+					description
+					locations
+					args { name type { kind ofType { name } } }
+				}
+			}
+		}`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	directives := result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["directives"].([]interface{})
+	expectedDirective := map[string]interface{}{
+		"name":        "auth",
+		"description": "Restricts a field to users with the given role.",
+		"locations":   []interface{}{"FIELD_DEFINITION"},
+		"args": []interface{}{
+			map[string]interface{}{
+				"name": "role",
+				"type": map[string]interface{}{
+					"kind":   "NON_NULL",
+					"ofType": map[string]interface{}{"name": "String"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedDirective, directives[0]) {
+		t.Fatalf("Unexpected directive, Diff: %v", testutil.Diff(expectedDirective, directives[0]))
+	}
+}
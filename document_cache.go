@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/sprucehealth/graphql/language/ast"
+)
+
+// CachedDocument is a parsed request along with the result of validating it.
+// The AST is shared by every request that uses it so it must not be modified.
+type CachedDocument struct {
+	AST        *ast.Document
+	Validation ValidationResult
+}
+
+// DocumentCache stores parsed and validated requests so that identical requests
+// aren't parsed and validated again. Keys identify both the request string and
+// the schema. Implementations must be safe for concurrent use.
+type DocumentCache interface {
+	Get(key string) (*CachedDocument, bool)
+	Set(key string, doc *CachedDocument)
+}
+
+type lruDocumentCacheEntry struct {
+	key string
+	doc *CachedDocument
+}
+
+// LRUDocumentCache is a DocumentCache that holds a fixed number of documents,
+// evicting the least recently used one when it's full.
+type LRUDocumentCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of *lruDocumentCacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+// NewLRUDocumentCache returns a cache that holds up to size documents.
+func NewLRUDocumentCache(size int) *LRUDocumentCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUDocumentCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+// Get returns the document cached for the key.
+func (c *LRUDocumentCache) Get(key string) (*CachedDocument, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruDocumentCacheEntry).doc, true
+}
+
+// Set caches the document for the key, evicting the least recently used
+// document if the cache is full.
+func (c *LRUDocumentCache) Set(key string, doc *CachedDocument) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruDocumentCacheEntry).doc = doc
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruDocumentCacheEntry{key: key, doc: doc})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruDocumentCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached documents.
+func (c *LRUDocumentCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
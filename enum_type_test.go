@@ -2,6 +2,7 @@ package graphql_test
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
@@ -377,3 +378,239 @@ func TestTypeSystem_EnumValues_EnumValueMayBeNullable(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+type enumTypeTestStringer int
+
+func (s enumTypeTestStringer) String() string {
+	switch s {
+	case 1:
+		return "GREEN"
+	}
+	return "UNKNOWN"
+}
+
+func TestTypeSystem_EnumValues_SerializesStringerByName(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"stringer": &graphql.Field{
+					Type: enumTypeTestColorType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return enumTypeTestStringer(1), nil
+					},
+				},
+				"unknownStringer": &graphql.Field{
+					Type: enumTypeTestColorType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return enumTypeTestStringer(7), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"stringer":        "GREEN",
+			"unknownStringer": nil,
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ stringer unknownStringer }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypeSystem_EnumValues_SerializesListAndNestedValues(t *testing.T) {
+	type status int
+	const (
+		statusActive status = iota + 1
+		statusArchived
+	)
+	statusType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Status",
+		Values: graphql.EnumValueConfigMap{
+			"ACTIVE":   &graphql.EnumValueConfig{Value: statusActive},
+			"ARCHIVED": &graphql.EnumValueConfig{Value: statusArchived},
+		},
+	})
+	type item struct {
+		Status   status   `json:"status"`
+		Statuses []status `json:"statuses"`
+	}
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"status":   &graphql.Field{Type: statusType},
+			"statuses": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(statusType))},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"statuses": &graphql.Field{
+					Type: graphql.NewList(statusType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []status{statusArchived, statusActive, statusArchived}, nil
+					},
+				},
+				"statusArray": &graphql.Field{
+					Type: graphql.NewList(statusType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return [2]status{statusActive, statusArchived}, nil
+					},
+				},
+				"items": &graphql.Field{
+					Type: graphql.NewList(itemType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []*item{
+							{Status: statusActive, Statuses: []status{statusActive}},
+							{Status: statusArchived, Statuses: []status{statusArchived, statusActive}},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"statuses":    []interface{}{"ARCHIVED", "ACTIVE", "ARCHIVED"},
+			"statusArray": []interface{}{"ACTIVE", "ARCHIVED"},
+			"items": []interface{}{
+				map[string]interface{}{
+					"status":   "ACTIVE",
+					"statuses": []interface{}{"ACTIVE"},
+				},
+				map[string]interface{}{
+					"status":   "ARCHIVED",
+					"statuses": []interface{}{"ARCHIVED", "ACTIVE"},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ statuses statusArray items { status statuses } }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypeSystem_EnumValues_ReportsDeprecatedInput(t *testing.T) {
+	newSchema := func(strict bool) graphql.Schema {
+		sizeType := graphql.NewEnum(graphql.EnumConfig{
+			Name: "Size",
+			Values: graphql.EnumValueConfigMap{
+				"LARGE": &graphql.EnumValueConfig{},
+				"HUGE":  &graphql.EnumValueConfig{DeprecationReason: "Use LARGE."},
+			},
+			WarnDeprecatedInput:   true,
+			StrictDeprecatedInput: strict,
+		})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"size": &graphql.Field{
+						Type: graphql.String,
+						Args: graphql.FieldConfigArgument{
+							"size": &graphql.ArgumentConfig{Type: sizeType},
+						},
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return p.Args["size"], nil
+						},
+					},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	query := `query q($size: Size) { a: size(size: HUGE) b: size(size: $size) }`
+	vars := map[string]interface{}{"size": "HUGE"}
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "HUGE",
+			"b": "HUGE",
+		},
+		Extensions: map[string]interface{}{
+			"warnings": []gqlerrors.FormattedError{
+				{
+					Message:   `The enum value "Size.HUGE" is deprecated. Use LARGE.`,
+					Locations: []location.SourceLocation{{Line: 1, Column: 9}},
+				},
+				{
+					Message:   `The enum value "Size.HUGE" is deprecated. Use LARGE.`,
+					Locations: []location.SourceLocation{{Line: 1, Column: 38}},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:         newSchema(false),
+		RequestString:  query,
+		VariableValues: vars,
+	})
+	if warnings, ok := result.Extensions["warnings"].([]gqlerrors.FormattedError); ok && len(warnings) == 2 {
+		sort.Sort(gqlerrors.FormattedErrors(warnings))
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// In strict mode the deprecated variable value rejects the request.
+	expected = &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `The enum value "Size.HUGE" is deprecated. Use LARGE.`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 9}},
+			},
+		},
+	}
+	result = g(t, graphql.Params{
+		Schema:         newSchema(true),
+		RequestString:  query,
+		VariableValues: vars,
+	})
+	if !testutil.EqualErrorMessage(expected, result, 0) || result.Data != nil {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// And a deprecated literal is a field error.
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"a": nil,
+			"b": "LARGE",
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `The enum value "Size.HUGE" is deprecated. Use LARGE.`,
+				Type:      gqlerrors.ErrorTypeInvalidInput,
+				Locations: []location.SourceLocation{{Line: 1, Column: 38}},
+				Path:      []interface{}{"a"},
+			},
+		},
+	}
+	result = g(t, graphql.Params{
+		Schema:         newSchema(true),
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"size": "LARGE"},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
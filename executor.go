@@ -2,14 +2,21 @@ package graphql
 
 import (
 	"context"
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/printer"
+	"github.com/sprucehealth/graphql/language/visitor"
 )
 
 type ExecuteParams struct {
@@ -22,30 +29,96 @@ type ExecuteParams struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// Debug includes the stack trace of panicking resolvers in the
+	// extensions of the resulting field errors.
+	Debug bool
+
+	// ArgsCoerced indicates that Args have already been validated and coerced
+	// to the operation's variable types (e.g. by an upstream gateway), so the
+	// coercion pass is skipped. Only default values for missing variables are
+	// filled in.
+	ArgsCoerced bool
+
+	// LenientBooleans accepts 0/1 and "true"/"false" for Boolean variables.
+	LenientBooleans bool
+
+	// BeforeExecute, when set, is called with the selected operation and its
+	// coerced variables just before execution starts.
+	BeforeExecute BeforeExecuteFn
+
+	// PartialResultsOnTimeout makes execution return the fields resolved before
+	// the context is done, with an error at the path of each field or list item
+	// that wasn't, rather than a single error. Execution then waits for running
+	// resolvers to return so they must honor the context. Resolvers may return a
+	// Deferred to wait for work they started in a goroutine.
+	PartialResultsOnTimeout bool
+
+	// MaxFields, when greater than zero, aborts execution once more than this
+	// many fields have been resolved.
+	MaxFields int
+
+	// MaxDepth, when greater than zero, aborts execution when resolving a field
+	// nested deeper than this many fields.
+	MaxDepth int
+
+	// MaxResponseBytes, when greater than zero, aborts execution once the JSON
+	// encoded data completed so far is larger than this many bytes. The size is
+	// counted as values are completed, ignoring the escaping of strings.
+	MaxResponseBytes int
 }
 
+// BeforeExecuteFn is called once a request has been parsed, validated, and its
+// variables coerced but before it's executed. Returning an error rejects the
+// request. The returned context, if not nil, replaces the one that's passed to
+// resolvers so it may be annotated.
+type BeforeExecuteFn func(ctx context.Context, op *ast.OperationDefinition, vars map[string]interface{}) (context.Context, error)
+
+// Deferred may be returned by a resolver to wait for work it started in a
+// goroutine, so that its sibling fields are resolved in the meantime. It's
+// called with the request's context once the siblings are resolved, and the
+// value it returns is completed as the field's value. It's called on the
+// executing goroutine, so it must return once the context is done: the field
+// then gets the context's error.
+type Deferred func(ctx context.Context) (interface{}, error)
+
 func Execute(p ExecuteParams) (result *Result) {
 	// Use background context if no context was provided
 	ctx := p.Context
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	extensions := &Extensions{}
+	execCtx := context.WithValue(ctx, extensionsKey{}, extensions)
 
 	resultChannel := make(chan *Result, 1)
+	done := ctx.Done()
+	if p.PartialResultsOnTimeout {
+		done = nil
+	}
 
 	go func(out chan<- *Result, done <-chan struct{}) {
 
 		result := &Result{}
 
 		exeContext, err := buildExecutionContext(BuildExecutionCtxParams{
-			Schema:        p.Schema,
-			Root:          p.Root,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Errors:        nil,
-			Result:        result,
-			Context:       p.Context,
+			Schema:          p.Schema,
+			Root:            p.Root,
+			AST:             p.AST,
+			OperationName:   p.OperationName,
+			Args:            p.Args,
+			Errors:          nil,
+			Result:          result,
+			Context:         execCtx,
+			Debug:           p.Debug,
+			ArgsCoerced:     p.ArgsCoerced,
+			LenientBooleans: p.LenientBooleans,
+			BeforeExecute:   p.BeforeExecute,
+
+			PartialResultsOnTimeout: p.PartialResultsOnTimeout,
+			MaxFields:               p.MaxFields,
+			MaxDepth:                p.MaxDepth,
+			MaxResponseBytes:        p.MaxResponseBytes,
 		})
 
 		if err != nil {
@@ -59,10 +132,14 @@ func Execute(p ExecuteParams) (result *Result) {
 
 		defer func() {
 			if r := recover(); r != nil {
+				if abort, ok := r.(executionAborted); ok {
+					r = abort.err
+				}
 				err := gqlerrors.FormatPanic(r)
 				exeContext.Errors = append(exeContext.Errors, gqlerrors.FormatError(err))
 				result.Errors = exeContext.Errors
 			}
+			extensions.merge(result)
 			select {
 			case out <- result:
 			case <-done:
@@ -74,10 +151,10 @@ func Execute(p ExecuteParams) (result *Result) {
 			Root:             p.Root,
 			Operation:        exeContext.Operation,
 		})
-	}(resultChannel, ctx.Done())
+	}(resultChannel, done)
 
 	select {
-	case <-ctx.Done():
+	case <-done:
 		result = &Result{}
 		result.Errors = append(result.Errors, gqlerrors.FormatError(ctx.Err()))
 	case r := <-resultChannel:
@@ -86,15 +163,39 @@ func Execute(p ExecuteParams) (result *Result) {
 	return
 }
 
+// ExecuteWithTimeout executes the request with a context that's done after the
+// given duration. The result holds the data resolved by then along with a
+// timeout error for each field that wasn't (see PartialResultsOnTimeout).
+func ExecuteWithTimeout(p ExecuteParams, d time.Duration) *Result {
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	p.Context = ctx
+	p.PartialResultsOnTimeout = true
+	return Execute(p)
+}
+
 type BuildExecutionCtxParams struct {
-	Schema        Schema
-	Root          interface{}
-	AST           *ast.Document
-	OperationName string
-	Args          map[string]interface{}
-	Errors        []gqlerrors.FormattedError
-	Result        *Result
-	Context       context.Context
+	Schema          Schema
+	Root            interface{}
+	AST             *ast.Document
+	OperationName   string
+	Args            map[string]interface{}
+	Errors          []gqlerrors.FormattedError
+	Result          *Result
+	Context         context.Context
+	Debug           bool
+	ArgsCoerced     bool
+	LenientBooleans bool
+	BeforeExecute   BeforeExecuteFn
+
+	PartialResultsOnTimeout bool
+	MaxFields               int
+	MaxDepth                int
+	MaxResponseBytes        int
 }
 type ExecutionContext struct {
 	Schema         Schema
@@ -104,6 +205,21 @@ type ExecutionContext struct {
 	VariableValues map[string]interface{}
 	Errors         []gqlerrors.FormattedError
 	Context        context.Context
+	Debug          bool
+	OperationName  string
+	RawQuery       string
+
+	PartialResultsOnTimeout bool
+	MaxFields               int
+	MaxDepth                int
+	MaxResponseBytes        int
+
+	// resolvedFields counts the fields resolved so far to enforce MaxFields.
+	resolvedFields int
+	literals       literalCache
+	// responseBytes counts the encoded size of the data completed so far to
+	// enforce MaxResponseBytes.
+	responseBytes int
 }
 
 func safeNodeType(n ast.Node) string {
@@ -111,6 +227,9 @@ func safeNodeType(n ast.Node) string {
 }
 
 func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error) {
+	if p.Schema.QueryType() == nil {
+		return nil, errors.New(errMissingQueryType)
+	}
 	var operation *ast.OperationDefinition
 	fragments := make(map[string]*ast.FragmentDefinition)
 	for _, definition := range p.AST.Definitions {
@@ -140,9 +259,38 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 		return nil, errors.New("Must provide an operation.")
 	}
 
-	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
-	if err != nil {
-		return nil, err
+	var variableValues map[string]interface{}
+	if p.ArgsCoerced {
+		variableValues = coercedVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
+	} else {
+		var err error
+		variableValues, err = getVariableValues(p.Context, p.Schema, operation.GetVariableDefinitions(), p.Args, p.LenientBooleans)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := p.Context
+	if p.BeforeExecute != nil {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		newCtx, err := p.BeforeExecute(ctx, operation, variableValues)
+		if err != nil {
+			return nil, err
+		}
+		if newCtx != nil {
+			ctx = newCtx
+		}
+	}
+
+	var operationName string
+	if operation.Name != nil {
+		operationName = operation.Name.Value
+	}
+	var rawQuery string
+	if p.AST.Loc.Source != nil {
+		rawQuery = p.AST.Loc.Source.Body()
 	}
 
 	eCtx := &ExecutionContext{
@@ -152,7 +300,15 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 		Operation:      operation,
 		VariableValues: variableValues,
 		Errors:         p.Errors,
-		Context:        p.Context,
+		Context:        ctx,
+		Debug:          p.Debug,
+		OperationName:  operationName,
+		RawQuery:       rawQuery,
+
+		PartialResultsOnTimeout: p.PartialResultsOnTimeout,
+		MaxFields:               p.MaxFields,
+		MaxDepth:                p.MaxDepth,
+		MaxResponseBytes:        p.MaxResponseBytes,
 	}
 	return eCtx, nil
 }
@@ -169,10 +325,12 @@ func executeOperation(p ExecuteOperationParams) *Result {
 		return &Result{Errors: gqlerrors.FormatErrors(err)}
 	}
 
+	var fieldOrder []string
 	fields := collectFields(CollectFieldsParams{
 		ExeContext:   p.ExecutionContext,
 		RuntimeType:  operationType,
 		SelectionSet: p.Operation.GetSelectionSet(),
+		FieldOrder:   &fieldOrder,
 	})
 
 	executeFieldsParams := ExecuteFieldsParams{
@@ -180,6 +338,7 @@ func executeOperation(p ExecuteOperationParams) *Result {
 		ParentType:       operationType,
 		Source:           p.Root,
 		Fields:           fields,
+		FieldOrder:       fieldOrder,
 	}
 
 	if p.Operation.GetOperation() == ast.OperationTypeMutation {
@@ -242,6 +401,10 @@ type ExecuteFieldsParams struct {
 	ParentType       *Object
 	Source           interface{}
 	Fields           map[string][]*ast.Field
+	Path             []interface{}
+	// FieldOrder holds the response names of Fields in the order they're
+	// selected. Fields are resolved serially in this order.
+	FieldOrder []string
 }
 
 // Implements the "Evaluating selection sets" section of the spec for "write" mode.
@@ -254,11 +417,24 @@ func executeFieldsSerially(p ExecuteFieldsParams) *Result {
 	}
 
 	finalResults := make(map[string]interface{})
-	for responseName, fieldASTs := range p.Fields {
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs)
+	for _, responseName := range p.FieldOrder {
+		fieldASTs, ok := p.Fields[responseName]
+		if !ok {
+			continue
+		}
+		// Resolvers get a copy of the siblings resolved so far so that they
+		// don't see later ones or modify the results.
+		siblings := make(map[string]interface{}, len(finalResults))
+		for name, value := range finalResults {
+			siblings[name] = value
+		}
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, appendPath(p.Path, responseName), siblings)
 		if state.hasNoFieldDefs {
 			continue
 		}
+		if state.deferred != nil {
+			resolved = state.deferred()
+		}
 		finalResults[responseName] = resolved
 	}
 
@@ -278,13 +454,24 @@ func executeFields(p ExecuteFieldsParams) *Result {
 	}
 
 	finalResults := make(map[string]interface{})
+	var deferred map[string]func() interface{}
 	for responseName, fieldASTs := range p.Fields {
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs)
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, appendPath(p.Path, responseName), nil)
 		if state.hasNoFieldDefs {
 			continue
 		}
+		if state.deferred != nil {
+			if deferred == nil {
+				deferred = make(map[string]func() interface{})
+			}
+			deferred[responseName] = state.deferred
+			continue
+		}
 		finalResults[responseName] = resolved
 	}
+	for responseName, complete := range deferred {
+		finalResults[responseName] = complete()
+	}
 
 	return &Result{
 		Data:   finalResults,
@@ -298,6 +485,9 @@ type CollectFieldsParams struct {
 	SelectionSet         *ast.SelectionSet
 	Fields               map[string][]*ast.Field
 	VisitedFragmentNames map[string]struct{}
+	// FieldOrder, if set, has the response names of newly collected fields
+	// appended in the order they're selected.
+	FieldOrder *[]string
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
@@ -305,6 +495,9 @@ type CollectFieldsParams struct {
 // CollectFields requires the "runtime type" of an object. For a field which
 // returns and Interface or Union type, the "runtime type" will be the actual
 // Object type returned by that field.
+// The @skip and @include directives are evaluated for each occurrence of a
+// field before it's merged with others of the same response name, so a field
+// skipped in one fragment is still included when selected by another.
 func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 	fields := p.Fields
 	if fields == nil {
@@ -323,6 +516,9 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				continue
 			}
 			name := getFieldEntryKey(selection)
+			if _, ok := fields[name]; !ok && p.FieldOrder != nil {
+				*p.FieldOrder = append(*p.FieldOrder, name)
+			}
 			fields[name] = append(fields[name], selection)
 		case *ast.InlineFragment:
 
@@ -336,6 +532,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				SelectionSet:         selection.SelectionSet,
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				FieldOrder:           p.FieldOrder,
 			}
 			collectFields(innerParams)
 		case *ast.FragmentSpread:
@@ -362,6 +559,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				SelectionSet:         fragment.GetSelectionSet(),
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				FieldOrder:           p.FieldOrder,
 			}
 			collectFields(innerParams)
 		}
@@ -387,9 +585,11 @@ func shouldIncludeNode(eCtx *ExecutionContext, directives []*ast.Directive) bool
 	}
 	if skipAST != nil {
 		argValues, err := getArgumentValues(
+			eCtx.Context,
 			SkipDirective.Args,
 			skipAST.Arguments,
 			eCtx.VariableValues,
+			&eCtx.literals,
 		)
 		if err != nil {
 			return defaultReturnValue
@@ -411,9 +611,11 @@ func shouldIncludeNode(eCtx *ExecutionContext, directives []*ast.Directive) bool
 	}
 	if includeAST != nil {
 		argValues, err := getArgumentValues(
+			eCtx.Context,
 			IncludeDirective.Args,
 			includeAST.Arguments,
 			eCtx.VariableValues,
+			&eCtx.literals,
 		)
 		if err != nil {
 			return defaultReturnValue
@@ -486,34 +688,143 @@ func getFieldEntryKey(node *ast.Field) string {
 	return ""
 }
 
+// contextErr returns the error of the execution's context once it's done.
+func contextErr(eCtx *ExecutionContext) error {
+	if eCtx.Context == nil {
+		return nil
+	}
+	return eCtx.Context.Err()
+}
+
+// contextFieldError returns the error for a field or list item at path that
+// wasn't resolved before the context was done.
+func contextFieldError(err error, fieldASTs []*ast.Field, path []interface{}) gqlerrors.FormattedError {
+	fieldErr := gqlerrors.FormatError(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs)))
+	fieldErr.Path = path
+	return fieldErr
+}
+
+// executionAborted is panicked with to abort the whole execution rather than
+// only the field being resolved.
+type executionAborted struct {
+	err gqlerrors.FormattedError
+}
+
+// checkExecutionLimits aborts the execution if resolving the field at path
+// exceeds MaxFields or MaxDepth.
+func checkExecutionLimits(eCtx *ExecutionContext, fieldASTs []*ast.Field, path []interface{}) {
+	var message string
+	if eCtx.MaxFields > 0 {
+		eCtx.resolvedFields++
+		if eCtx.resolvedFields > eCtx.MaxFields {
+			message = fmt.Sprintf("Execution exceeded the maximum of %d fields.", eCtx.MaxFields)
+		}
+	}
+	if eCtx.MaxDepth > 0 && message == "" {
+		depth := 0
+		for _, key := range path {
+			if _, ok := key.(string); ok {
+				depth++
+			}
+		}
+		if depth > eCtx.MaxDepth {
+			message = fmt.Sprintf("Execution exceeded the maximum depth of %d.", eCtx.MaxDepth)
+		}
+	}
+	if message != "" {
+		abortExecution(message, fieldASTs, path)
+	}
+}
+
+// countResponseBytes adds the encoded size n of the value completed at path,
+// along with its key or separator, to the size of the response and aborts the
+// execution if it exceeds MaxResponseBytes. Lists and objects only count their
+// delimiters as their items are counted as they're completed.
+func countResponseBytes(eCtx *ExecutionContext, fieldASTs []*ast.Field, path []interface{}, n int) {
+	if eCtx.MaxResponseBytes <= 0 {
+		return
+	}
+	if len(path) != 0 {
+		if key, ok := path[len(path)-1].(string); ok {
+			n += len(key) + len(`"":,`)
+		} else {
+			n += len(",")
+		}
+	}
+	eCtx.responseBytes += n
+	if eCtx.responseBytes > eCtx.MaxResponseBytes {
+		abortExecution(fmt.Sprintf("Response exceeds the maximum size of %d bytes.", eCtx.MaxResponseBytes), fieldASTs, path)
+	}
+}
+
+// countLeafResponseBytes counts the encoded size of a completed leaf value
+// (see countResponseBytes) and returns the value.
+func countLeafResponseBytes(eCtx *ExecutionContext, fieldASTs []*ast.Field, path []interface{}, value interface{}) interface{} {
+	if eCtx.MaxResponseBytes <= 0 {
+		return value
+	}
+	var n int
+	switch v := value.(type) {
+	case nil:
+		n = len("null")
+	case string:
+		n = len(v) + len(`""`)
+	case bool:
+		n = len(strconv.FormatBool(v))
+	case int:
+		n = len(strconv.Itoa(v))
+	default:
+		b, _ := json.Marshal(v)
+		n = len(b)
+	}
+	countResponseBytes(eCtx, fieldASTs, path, n)
+	return value
+}
+
+// abortExecution aborts the whole execution with an error at path.
+func abortExecution(message string, fieldASTs []*ast.Field, path []interface{}) {
+	err := gqlerrors.FormatError(gqlerrors.NewError(
+		gqlerrors.ErrorTypeBadQuery,
+		message,
+		FieldASTsToNodeASTs(fieldASTs),
+		"",
+		nil,
+		[]int{},
+		nil,
+	))
+	err.Path = path
+	panic(executionAborted{err: err})
+}
+
+// appendPath returns a copy of path with key appended so that sibling fields
+// and list items never share a backing array.
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	p := make([]interface{}, len(path)+1)
+	copy(p, path)
+	p[len(path)] = key
+	return p
+}
+
 // Internal resolveField state
 type resolveFieldResultState struct {
 	hasNoFieldDefs bool
+	// deferred completes the field once its siblings are resolved.
+	deferred func() interface{}
 }
 
 // Resolves the field on the given source object. In particular, this
 // figures out the value that the field returns by calling its resolve function,
 // then calls completeValue to complete promises, serialize scalars, or execute
 // the sub-selection-set for objects.
-func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}, fieldASTs []*ast.Field) (result interface{}, resultState resolveFieldResultState) {
+func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}, fieldASTs []*ast.Field, path []interface{}, siblings map[string]interface{}) (result interface{}, resultState resolveFieldResultState) {
 	// catch panic from resolveFn
 	var returnType Output
-	defer func() (interface{}, resolveFieldResultState) {
+	defer func() {
 		if r := recover(); r != nil {
-			var err error
-			if s, ok := r.(string); ok {
-				err = NewLocatedError(s, FieldASTsToNodeASTs(fieldASTs))
-			} else {
-				err = gqlerrors.FormatPanic(r)
-			}
-			// send panic upstream
-			if _, ok := returnType.(*NonNull); ok {
-				panic(gqlerrors.FormatError(err))
-			}
-			eCtx.Errors = append(eCtx.Errors, gqlerrors.FormatError(err))
-			return result, resultState
+			// The field is null rather than whatever was resolved before the panic.
+			result = nil
+			recordFieldPanic(eCtx, r, returnType, fieldASTs, path)
 		}
-		return result, resultState
 	}()
 
 	fieldAST := fieldASTs[0]
@@ -528,15 +839,36 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 		return nil, resultState
 	}
 	returnType = fieldDef.Type
+	checkExecutionLimits(eCtx, fieldASTs, path)
+
+	// The result of __schema depends only on the schema and the selection, so it's
+	// cached to avoid walking the whole schema again for repeated introspection.
+	var introspectionKey string
+	if fieldDef == SchemaMetaFieldDef && eCtx.Schema.introspectionCache != nil {
+		if key, ok := introspectionCacheKey(eCtx, fieldASTs); ok {
+			if cached, ok := eCtx.Schema.introspectionCache.load(key); ok {
+				return cached, resultState
+			}
+			introspectionKey = key
+		}
+	}
+
 	resolveFn := fieldDef.Resolve
 	if resolveFn == nil {
 		resolveFn = defaultResolveFn
 	}
 
+	if err := contextErr(eCtx); err != nil {
+		panic(contextFieldError(err, fieldASTs, path))
+	}
+
 	// Build a map of arguments from the field.arguments AST, using the
-	// variables scope to fulfill any variable references.
-	// TODO: find a way to memoize, in case this field is within a List type.
-	args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	// variables scope to fulfill any variable references. List and input object
+	// literals are only coerced once, in case this field is within a List type.
+	args, err := getArgumentValues(eCtx.Context, fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues, &eCtx.literals)
+	if err != nil {
+		panic(gqlerrors.FormatError(err))
+	}
 
 	info := ResolveInfo{
 		FieldName:      fieldName,
@@ -548,6 +880,11 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 		RootValue:      eCtx.Root,
 		Operation:      eCtx.Operation,
 		VariableValues: eCtx.VariableValues,
+		OperationName:  eCtx.OperationName,
+		RawQuery:       eCtx.RawQuery,
+		Path:           path,
+
+		ResolvedSiblings: siblings,
 	}
 
 	var resolveFnError error
@@ -563,14 +900,211 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 		panic(gqlerrors.FormatError(resolveFnError))
 	}
 
+	if isMutationRootField(eCtx, parentType) {
+		if ch := reflect.ValueOf(result); ch.IsValid() && ch.Kind() == reflect.Chan && !ch.IsNil() && ch.Type().ChanDir()&reflect.RecvDir != 0 {
+			if _, ok := GetNullable(returnType).(*List); !ok {
+				result = awaitLastChanValue(eCtx, fieldASTs, path, ch)
+			}
+		}
+	}
+
+	if wait, ok := result.(Deferred); ok {
+		resultState.deferred = func() interface{} {
+			return completeDeferredField(eCtx, returnType, fieldASTs, info, wait)
+		}
+		return nil, resultState
+	}
+
+	numErrors := len(eCtx.Errors)
 	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, result)
+	if introspectionKey != "" && len(eCtx.Errors) == numErrors {
+		eCtx.Schema.introspectionCache.store(introspectionKey, completed)
+	}
 	return completed, resultState
 }
 
+// maxIntrospectionCacheEntries bounds the number of __schema selections whose
+// results are cached, since the selections are chosen by clients.
+const maxIntrospectionCacheEntries = 16
+
+// introspectionCache holds the completed values of __schema selections.
+// Values are copied in and out so that callers can't modify the cached ones.
+type introspectionCache struct {
+	mu      sync.RWMutex
+	entries map[string]interface{} // printed __schema selection -> completed value
+}
+
+func (c *introspectionCache) load(key string) (interface{}, bool) {
+	c.mu.RLock()
+	value, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return copyValue(value), true
+}
+
+// store caches the value unless the cache is already full.
+func (c *introspectionCache) store(key string, value interface{}) {
+	value = copyValue(value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok || len(c.entries) >= maxIntrospectionCacheEntries {
+		return
+	}
+	c.entries[key] = value
+}
+
+// copyValue returns a deep copy of the objects and lists of a completed or
+// coerced value.
+func copyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			copied[k] = copyValue(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, v := range value {
+			copied[i] = copyValue(v)
+		}
+		return copied
+	}
+	return value
+}
+
+// introspectionCacheKey returns the key used to cache the completed value of an
+// introspection field. It's made up of the printed field selections and the
+// fragments they spread. Selections that reference variables aren't cacheable.
+func introspectionCacheKey(eCtx *ExecutionContext, fieldASTs []*ast.Field) (string, bool) {
+	cacheable := true
+	var pending []ast.Node
+	seenFragments := make(map[string]struct{})
+	opts := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Variable:
+				cacheable = false
+				return visitor.ActionBreak, nil
+			case *ast.FragmentSpread:
+				if node.Name == nil {
+					break
+				}
+				name := node.Name.Value
+				if _, ok := seenFragments[name]; ok {
+					break
+				}
+				seenFragments[name] = struct{}{}
+				fragment, ok := eCtx.Fragments[name]
+				if !ok {
+					cacheable = false
+					return visitor.ActionBreak, nil
+				}
+				pending = append(pending, fragment)
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+
+	for _, fieldAST := range fieldASTs {
+		pending = append(pending, fieldAST)
+	}
+	var key strings.Builder
+	for i := 0; i < len(pending); i++ {
+		visitor.Visit(pending[i], opts)
+		if !cacheable {
+			return "", false
+		}
+		key.WriteString(printer.Print(pending[i]))
+		key.WriteByte('\n')
+	}
+	return key.String(), true
+}
+
+// completeDeferredField waits for the Deferred returned by a field's resolver
+// and completes the value it returns.
+func completeDeferredField(eCtx *ExecutionContext, returnType Output, fieldASTs []*ast.Field, info ResolveInfo, wait Deferred) (completed interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			completed = nil
+			recordFieldPanic(eCtx, r, returnType, fieldASTs, info.Path)
+		}
+	}()
+	if err := contextErr(eCtx); err != nil {
+		panic(contextFieldError(err, fieldASTs, info.Path))
+	}
+	ctx := eCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	value, err := wait(ctx)
+	if err := contextErr(eCtx); err != nil {
+		panic(contextFieldError(err, fieldASTs, info.Path))
+	}
+	if err != nil {
+		panic(gqlerrors.FormatError(err))
+	}
+	return completeValueCatchingError(eCtx, returnType, fieldASTs, info, value)
+}
+
+// recoveredFieldError converts a value recovered from a panic while resolving
+// a field into a located field error. Errors that were already formatted (e.g.
+// from a child field or a resolver error) are passed through untouched.
+func recoveredFieldError(eCtx *ExecutionContext, r interface{}, fieldASTs []*ast.Field) gqlerrors.FormattedError {
+	if err, ok := r.(gqlerrors.FormattedError); ok {
+		return err
+	}
+	var located *gqlerrors.Error
+	switch r := r.(type) {
+	case string, error:
+		located = NewLocatedError(r, FieldASTsToNodeASTs(fieldASTs))
+	default:
+		located = NewLocatedError(fmt.Sprintf("%v", r), FieldASTsToNodeASTs(fieldASTs))
+	}
+	err := gqlerrors.FormatError(located)
+	if eCtx.Debug {
+		err.StackTrace = string(debug.Stack())
+		err.Extensions = map[string]interface{}{
+			"stack": err.StackTrace,
+		}
+	}
+	return err
+}
+
+// recordFieldPanic records the value recovered from a panic while resolving the
+// field at path as a field error, or sends it upstream if the field is non-null.
+func recordFieldPanic(eCtx *ExecutionContext, r interface{}, returnType Output, fieldASTs []*ast.Field, path []interface{}) {
+	if r == nil {
+		return
+	}
+	if abort, ok := r.(executionAborted); ok {
+		panic(abort)
+	}
+	err := recoveredFieldError(eCtx, r, fieldASTs)
+	if err.Path == nil {
+		err.Path = path
+	}
+	// send panic upstream
+	if _, ok := returnType.(*NonNull); ok {
+		panic(err)
+	}
+	eCtx.Errors = append(eCtx.Errors, err)
+}
+
 func completeValueCatchingError(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) (completed interface{}) {
 	// catch panic
 	defer func() interface{} {
 		if r := recover(); r != nil {
+			if abort, ok := r.(executionAborted); ok {
+				panic(abort)
+			}
+			// Errors are attributed to the deepest path they occurred at.
+			if err, ok := r.(gqlerrors.FormattedError); ok && err.Path == nil {
+				err.Path = info.Path
+				r = err
+			}
 			//send panic upstream
 			if _, ok := returnType.(*NonNull); ok {
 				panic(r)
@@ -616,9 +1150,22 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 
 	// If result value is null-ish (null, undefined, or NaN) then return null.
 	if isNullish(result) {
+		countResponseBytes(eCtx, fieldASTs, info.Path, len("null"))
 		return nil
 	}
 
+	// Pre-serialized JSON (e.g. a cached subtree) is emitted verbatim without
+	// completing it against the field's type or selection set, so it's up to the
+	// resolver to return JSON that matches them. A JSON null is treated as null.
+	if raw, ok := result.(json.RawMessage); ok {
+		if len(raw) == 0 || string(raw) == "null" {
+			countResponseBytes(eCtx, fieldASTs, info.Path, len("null"))
+			return nil
+		}
+		countResponseBytes(eCtx, fieldASTs, info.Path, len(raw))
+		return raw
+	}
+
 	// If field type is List, complete each item in the list with the inner type
 	if returnType, ok := returnType.(*List); ok {
 		return completeListValue(eCtx, returnType, fieldASTs, info, result)
@@ -627,10 +1174,10 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
 	// returning null if serialization is not possible.
 	if returnType, ok := returnType.(*Scalar); ok {
-		return completeLeafValue(returnType, result)
+		return countLeafResponseBytes(eCtx, fieldASTs, info.Path, completeLeafValue(returnType, fieldASTs, result))
 	}
 	if returnType, ok := returnType.(*Enum); ok {
-		return completeLeafValue(returnType, result)
+		return countLeafResponseBytes(eCtx, fieldASTs, info.Path, completeLeafValue(returnType, fieldASTs, result))
 	}
 
 	// If field type is an abstract type, Interface or Union, determine the
@@ -663,17 +1210,25 @@ func completeAbstractValue(eCtx *ExecutionContext, returnType Abstract, fieldAST
 	}
 	if unionReturnType, ok := returnType.(*Union); ok && unionReturnType.ResolveType != nil {
 		runtimeType = unionReturnType.ResolveType(resolveTypeParams)
+	} else if ok && unionReturnType.ResolveTypeName != nil {
+		runtimeType = loadRuntimeType(eCtx, unionReturnType.ResolveTypeName(resolveTypeParams))
 	} else if interfaceReturnType, ok := returnType.(*Interface); ok && interfaceReturnType.ResolveType != nil {
 		runtimeType = interfaceReturnType.ResolveType(resolveTypeParams)
+	} else if ok && interfaceReturnType.ResolveTypeName != nil {
+		runtimeType = loadRuntimeType(eCtx, interfaceReturnType.ResolveTypeName(resolveTypeParams))
 	} else {
 		runtimeType = defaultResolveTypeFn(resolveTypeParams, returnType)
 	}
 
 	if runtimeType == nil {
-		panic(gqlerrors.NewFormattedError(
+		err := gqlerrors.FormatError(NewLocatedError(
 			fmt.Sprintf(`Abstract type %v must resolve to an Object type at runtime `+
-				`for field %v.%v with value "%v", received "%v".`,
-				returnType, info.ParentType, info.FieldName, result, runtimeType)))
+				`for field %v.%v; received %T.`,
+				returnType, info.ParentType, info.FieldName, result),
+			FieldASTsToNodeASTs(fieldASTs),
+		))
+		err.Path = info.Path
+		panic(err)
 	}
 
 	if !eCtx.Schema.IsPossibleType(returnType, runtimeType) {
@@ -682,10 +1237,26 @@ func completeAbstractValue(eCtx *ExecutionContext, returnType Abstract, fieldAST
 				`for "%v".`, runtimeType, returnType),
 		))
 	}
+	// ResolveType may return a type of another schema with the same name, such
+	// as the schema that was extended or merged into this one.
+	if obj, ok := eCtx.Schema.Type(runtimeType.Name()).(*Object); ok {
+		runtimeType = obj
+	}
 
 	return completeObjectValue(eCtx, runtimeType, fieldASTs, info, result)
 }
 
+// loadRuntimeType returns the object type named by ResolveTypeName, or nil if
+// there's no such object type. A type that fails to load is a field error.
+func loadRuntimeType(eCtx *ExecutionContext, name string) *Object {
+	ttype, err := eCtx.Schema.LoadType(name)
+	if err != nil {
+		panic(gqlerrors.FormatError(err))
+	}
+	obj, _ := ttype.(*Object)
+	return obj
+}
+
 // completeObjectValue complete an Object value by executing all sub-selections.
 func completeObjectValue(eCtx *ExecutionContext, returnType *Object, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) interface{} {
 
@@ -705,6 +1276,8 @@ func completeObjectValue(eCtx *ExecutionContext, returnType *Object, fieldASTs [
 		}
 	}
 
+	countResponseBytes(eCtx, fieldASTs, info.Path, len("{}"))
+
 	// Collect sub-fields to execute to complete this value.
 	subFieldASTs := make(map[string][]*ast.Field)
 	visitedFragmentNames := make(map[string]struct{})
@@ -729,6 +1302,7 @@ func completeObjectValue(eCtx *ExecutionContext, returnType *Object, fieldASTs [
 		ParentType:       returnType,
 		Source:           result,
 		Fields:           subFieldASTs,
+		Path:             info.Path,
 	}
 	results := executeFields(executeFieldsParams)
 
@@ -737,9 +1311,32 @@ func completeObjectValue(eCtx *ExecutionContext, returnType *Object, fieldASTs [
 }
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
-func completeLeafValue(returnType Leaf, result interface{}) interface{} {
+// Built-in scalars throw a field error instead for values they can't serialize.
+func completeLeafValue(returnType Leaf, fieldASTs []*ast.Field, result interface{}) interface{} {
 	serializedResult := returnType.Serialize(result)
+	scalar, isScalar := returnType.(*Scalar)
+	// Values that a custom scalar doesn't know how to serialize are represented
+	// by their text form if they have one. Built-in scalars report them as
+	// errors instead, as e.g. the text form of a value isn't an Int.
+	if isScalar && !scalar.serializeErrors && isNullish(serializedResult) {
+		if v, ok := result.(encoding.TextMarshaler); ok {
+			serializedResult = marshalText(v)
+		}
+	}
+	// Pointers are serialized as the value they point to.
 	if isNullish(serializedResult) {
+		if resultVal := reflect.ValueOf(result); resultVal.Kind() == reflect.Ptr && !resultVal.IsNil() {
+			serializedResult = returnType.Serialize(resultVal.Elem().Interface())
+		}
+	}
+	if isNullish(serializedResult) {
+		if isScalar && scalar.serializeErrors {
+			err := NewLocatedError(
+				&SerializationError{Scalar: scalar.Name(), Value: result},
+				FieldASTsToNodeASTs(fieldASTs),
+			)
+			panic(gqlerrors.FormatError(err))
+		}
 		return nil
 	}
 	return serializedResult
@@ -752,20 +1349,98 @@ func completeListValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*as
 	if info.ParentType != nil {
 		parentTypeName = info.ParentType.Name()
 	}
-	if !resultVal.IsValid() || resultVal.Type().Kind() != reflect.Slice {
+	isChan := resultVal.IsValid() && resultVal.Kind() == reflect.Chan && resultVal.Type().ChanDir()&reflect.RecvDir != 0
+	if !isChan && (!resultVal.IsValid() || (resultVal.Kind() != reflect.Slice && resultVal.Kind() != reflect.Array)) {
 		panic(gqlerrors.NewFormattedError(fmt.Sprintf("User Error: expected iterable, but did not find one for field %v.%v.", parentTypeName, info.FieldName)))
 	}
+	countResponseBytes(eCtx, fieldASTs, info.Path, len("[]"))
+	if isChan {
+		return completeChanValue(eCtx, returnType, fieldASTs, info, resultVal)
+	}
 
 	itemType := returnType.OfType
 	completedResults := make([]interface{}, 0, resultVal.Len())
 	for i := 0; i < resultVal.Len(); i++ {
+		itemInfo := info
+		itemInfo.Path = appendPath(info.Path, i)
+		if err := contextErr(eCtx); err != nil {
+			// Items that aren't completed before the context is done get an error each.
+			fieldErr := contextFieldError(err, fieldASTs, itemInfo.Path)
+			if _, ok := itemType.(*NonNull); ok {
+				panic(fieldErr)
+			}
+			eCtx.Errors = append(eCtx.Errors, fieldErr)
+			completedResults = append(completedResults, nil)
+			continue
+		}
 		val := resultVal.Index(i).Interface()
-		completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, val)
+		completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, itemInfo, val)
 		completedResults = append(completedResults, completedItem)
 	}
 	return completedResults
 }
 
+// completeChanValue drains a channel returned for a list field, completing each
+// element, until the channel is closed or the context is cancelled.
+func completeChanValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*ast.Field, info ResolveInfo, ch reflect.Value) interface{} {
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: ch}}
+	if eCtx.Context != nil && eCtx.Context.Done() != nil {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(eCtx.Context.Done())})
+	}
+
+	completedResults := []interface{}{}
+	for {
+		chosen, val, ok := reflect.Select(cases)
+		if chosen == 1 {
+			panic(contextFieldError(eCtx.Context.Err(), fieldASTs, info.Path))
+		}
+		if !ok {
+			return completedResults
+		}
+		itemInfo := info
+		itemInfo.Path = appendPath(info.Path, len(completedResults))
+		completedItem := completeValueCatchingError(eCtx, returnType.OfType, fieldASTs, itemInfo, val.Interface())
+		completedResults = append(completedResults, completedItem)
+	}
+}
+
+// isMutationRootField returns true if fields of parentType are top level fields
+// of a mutation operation.
+func isMutationRootField(eCtx *ExecutionContext, parentType *Object) bool {
+	return eCtx.Operation != nil && eCtx.Operation.GetOperation() == ast.OperationTypeMutation &&
+		parentType == eCtx.Schema.MutationType()
+}
+
+// awaitLastChanValue receives from the channel returned by a mutation field that
+// isn't a list until it's closed, and returns the last value received (nil if
+// there was none) as the value of the field. This lets a mutation that starts
+// some work report its progress on a channel while the response includes only
+// the final outcome. Receiving an error ends the field with that error, and any
+// values received before it are discarded, so the sender should close the
+// channel after sending it. The context being done ends the field with the
+// context's error.
+func awaitLastChanValue(eCtx *ExecutionContext, fieldASTs []*ast.Field, path []interface{}, ch reflect.Value) interface{} {
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: ch}}
+	if eCtx.Context != nil && eCtx.Context.Done() != nil {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(eCtx.Context.Done())})
+	}
+
+	var last interface{}
+	for {
+		chosen, val, ok := reflect.Select(cases)
+		if chosen == 1 {
+			panic(contextFieldError(eCtx.Context.Err(), fieldASTs, path))
+		}
+		if !ok {
+			return last
+		}
+		last = val.Interface()
+		if err, ok := last.(error); ok {
+			panic(gqlerrors.FormatError(err))
+		}
+	}
+}
+
 type structFieldInfo struct {
 	index     int
 	omitempty bool
@@ -840,22 +1515,76 @@ func defaultResolveTypeFn(p ResolveTypeParams, abstractType Abstract) *Object {
 	return nil
 }
 
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// resolveMethod calls the exported zero-argument method on source named after
+// the field (with the first letter upper-cased). The method must return either
+// a single value or a value and an error. A source that isn't a pointer is
+// copied to an addressable value so that methods with a pointer receiver are
+// found too. The returned bool is false if no such method exists.
+func resolveMethod(source reflect.Value, fieldName string) (interface{}, bool, error) {
+	if !source.IsValid() || fieldName == "" {
+		return nil, false, nil
+	}
+	if source.Kind() != reflect.Ptr {
+		ptr := reflect.New(source.Type())
+		ptr.Elem().Set(source)
+		source = ptr
+	}
+	method := source.MethodByName(strings.ToUpper(fieldName[:1]) + fieldName[1:])
+	if !method.IsValid() {
+		return nil, false, nil
+	}
+	mt := method.Type()
+	if mt.NumIn() != 0 {
+		return nil, false, nil
+	}
+	switch mt.NumOut() {
+	case 1:
+		return method.Call(nil)[0].Interface(), true, nil
+	case 2:
+		if !mt.Out(1).Implements(errorType) {
+			return nil, false, nil
+		}
+		out := method.Call(nil)
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, true, err
+		}
+		return out[0].Interface(), true, nil
+	}
+	return nil, false, nil
+}
+
 // defaultResolveFn If a resolve function is not given, then a default resolve behavior is used
 // which takes the property of the source object of the same name as the field
 // and returns it as the result, or if it's a function, returns the result
-// of calling that function.
+// of calling that function. Functions in a map[string]interface{} may be thunks
+// (`func() interface{}` or `func() (interface{}, error)`) or resolvers
+// (`func(ResolveParams) (interface{}, error)`), which are only called when their
+// field is selected. Struct fields may be renamed using a `graphql` (or `json`)
+// tag. If the schema was created with ResolveMethods, a zero-argument method
+// named after the field (e.g. `Name()` for `name`) is used when no matching map
+// key or struct field exists.
 func defaultResolveFn(p ResolveParams) (interface{}, error) {
 	// try p.Source as a map[string]interface
 	if sourceMap, ok := p.Source.(map[string]interface{}); ok {
-		property := sourceMap[p.Info.FieldName]
-		if fn, ok := property.(func() interface{}); ok {
-			return fn(), nil
+		switch property := sourceMap[p.Info.FieldName].(type) {
+		case func() interface{}:
+			return property(), nil
+		case func() (interface{}, error):
+			return property()
+		case func(ResolveParams) (interface{}, error):
+			return property(p)
+		case FieldResolveFn:
+			return property(p)
+		default:
+			return property, nil
 		}
-		return property, nil
 	}
 
 	// try to resolve p.Source as a struct first
-	sourceVal := reflect.ValueOf(p.Source)
+	origVal := reflect.ValueOf(p.Source)
+	sourceVal := origVal
 	if sourceVal.IsValid() && sourceVal.Type().Kind() == reflect.Ptr {
 		sourceVal = sourceVal.Elem()
 	}
@@ -863,7 +1592,8 @@ func defaultResolveFn(p ResolveParams) (interface{}, error) {
 		return nil, nil
 	}
 	sourceType := sourceVal.Type()
-	if sourceType.Kind() == reflect.Struct {
+	switch sourceType.Kind() {
+	case reflect.Struct:
 		sm := fieldInfoForStruct(sourceType)
 		if field, ok := sm[p.Info.FieldName]; ok {
 			valueField := sourceVal.Field(field.index)
@@ -872,7 +1602,21 @@ func defaultResolveFn(p ResolveParams) (interface{}, error) {
 			}
 			return valueField.Interface(), nil
 		}
-		return nil, nil
+	case reflect.Map:
+		if sourceType.Key().Kind() == reflect.String {
+			v := sourceVal.MapIndex(reflect.ValueOf(p.Info.FieldName).Convert(sourceType.Key()))
+			if v.IsValid() {
+				return v.Interface(), nil
+			}
+		}
+	}
+
+	// fall back to a zero-argument method named after the field, except for
+	// the introspection types whose sources are the schema's own definitions
+	if p.Info.Schema.resolveMethods && p.Info.ParentType != nil && !strings.HasPrefix(p.Info.ParentType.Name(), "__") {
+		if v, ok, err := resolveMethod(origVal, p.Info.FieldName); ok {
+			return v, err
+		}
 	}
 
 	// last resort, return nil
@@ -902,5 +1646,8 @@ func getFieldDef(schema Schema, parentType *Object, fieldName string) *FieldDefi
 	if fieldName == TypeNameMetaFieldDef.Name {
 		return TypeNameMetaFieldDef
 	}
+	if !schema.fieldVisible(parentType.Name(), fieldName) {
+		return nil
+	}
 	return parentType.Fields()[fieldName]
 }
@@ -213,3 +213,77 @@ func BenchmarkQuery(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkQueryRepeatedLiteral(b *testing.B) {
+	filterType := NewInputObject(InputObjectConfig{
+		Name: "Filter",
+		Fields: InputObjectConfigFieldMap{
+			"tags":   &InputObjectFieldConfig{Type: NewList(String)},
+			"minAge": &InputObjectFieldConfig{Type: Int},
+			"sort": &InputObjectFieldConfig{Type: NewInputObject(InputObjectConfig{
+				Name: "Sort",
+				Fields: InputObjectConfigFieldMap{
+					"field":      &InputObjectFieldConfig{Type: NewNonNull(String)},
+					"descending": &InputObjectFieldConfig{Type: Boolean},
+				},
+			})},
+		},
+	})
+	itemType := NewObject(ObjectConfig{
+		Name: "Item",
+		Fields: Fields{
+			"matches": &Field{
+				Type: Boolean,
+				Args: FieldConfigArgument{
+					"filter": &ArgumentConfig{Type: filterType},
+				},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return p.Args["filter"] != nil, nil
+				},
+			},
+		},
+	})
+	items := make([]struct{}, 100)
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{
+			Name: "Query",
+			Fields: Fields{
+				"items": &Field{
+					Type: NewList(itemType),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return items, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		b.Fatalf("Error in schema %s", err)
+	}
+
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: `{
+			items {
+				matches(filter: {tags: ["a", "b", "c"], minAge: 3, sort: {field: "name", descending: true}})
+			}
+		}`,
+		Options: parser.ParseOptions{NoSource: true},
+	})
+	if err != nil {
+		b.Fatalf("Parse failed: %s", err)
+	}
+
+	ep := ExecuteParams{
+		Schema: schema,
+		AST:    astDoc,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := Execute(ep)
+		if len(result.Errors) > 0 {
+			b.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+	}
+}
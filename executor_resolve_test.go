@@ -2,7 +2,10 @@ package graphql_test
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
@@ -68,6 +71,89 @@ func TestExecutesResolveFunction_DefaultFunctionCallsMethods(t *testing.T) {
 	}
 }
 
+func TestExecutesResolveFunction_DefaultFunctionCallsThunksAndResolvers(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+				"b": &graphql.Field{Type: graphql.String},
+				"c": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"arg": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+				},
+				"d": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+
+	var mu sync.Mutex
+	var called []string
+	call := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		called = append(called, name)
+	}
+	source := map[string]interface{}{
+		"a": "a",
+		"b": func() (interface{}, error) {
+			call("b")
+			return "b", nil
+		},
+		"c": func(p graphql.ResolveParams) (interface{}, error) {
+			call("c")
+			return p.Args["arg"], nil
+		},
+		"d": func() (interface{}, error) {
+			call("d")
+			return nil, errors.New("d failed")
+		},
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a }`,
+		RootObject:    source,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "a",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if len(called) != 0 {
+		t.Fatalf("Expected no thunk or resolver to be called for unselected fields, called %v", called)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ b c(arg: "c") d }`,
+		RootObject:    source,
+	})
+	expectedData := map[string]interface{}{
+		"b": "b",
+		"c": "c",
+		"d": nil,
+	}
+	if !reflect.DeepEqual(expectedData, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedData, result.Data))
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != "d failed" {
+		t.Fatalf("Expected the error of d, got %v", result.Errors)
+	}
+	sort.Strings(called)
+	if !reflect.DeepEqual([]string{"b", "c", "d"}, called) {
+		t.Fatalf("Expected b, c, and d to be called once each, called %v", called)
+	}
+}
+
 func TestExecutesResolveFunction_UsesProvidedResolveFunction(t *testing.T) {
 	schema := testSchema(t, &graphql.Field{
 		Type: graphql.String,
@@ -265,3 +351,191 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
 	}
 }
+
+func TestExecutesResolveFunction_DefaultFunctionUsesGraphQLTags(t *testing.T) {
+	type tagged struct {
+		Name  string `graphql:"fullName" json:"name"`
+		Email string `json:"email"`
+	}
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Tagged",
+			Fields: graphql.Fields{
+				"fullName": &graphql.Field{Type: graphql.String},
+				"email":    &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return &tagged{Name: "Jane Doe", Email: "jane@example.com"}, nil
+		},
+	})
+
+	expected := map[string]interface{}{
+		"test": map[string]interface{}{
+			"fullName": "Jane Doe",
+			"email":    "jane@example.com",
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test { fullName, email } }`,
+	})
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestExecutesResolveFunction_DefaultFunctionAccessesTypedMapKeys(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Labels",
+			Fields: graphql.Fields{
+				"color": &graphql.Field{Type: graphql.String},
+				"size":  &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return map[string]string{"color": "red"}, nil
+		},
+	})
+
+	expected := map[string]interface{}{
+		"test": map[string]interface{}{
+			"color": "red",
+			"size":  nil,
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test { color, size } }`,
+	})
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+type resolveMethodSource struct {
+	first, last string
+}
+
+func (s *resolveMethodSource) FullName() string {
+	return s.first + " " + s.last
+}
+
+func (s *resolveMethodSource) Initials() (string, error) {
+	if s.first == "" || s.last == "" {
+		return "", errors.New("name is incomplete")
+	}
+	return s.first[:1] + s.last[:1], nil
+}
+
+// methodSchema returns a schema whose test field resolves to the value returned
+// by source, with the fields of Person resolved by the methods of the value if
+// resolveMethods is set.
+func methodSchema(t *testing.T, resolveMethods bool, source func(last string) interface{}) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"test": &graphql.Field{
+					Type: graphql.NewObject(graphql.ObjectConfig{
+						Name: "Person",
+						Fields: graphql.Fields{
+							"fullName": &graphql.Field{Type: graphql.String},
+							"initials": &graphql.Field{Type: graphql.String},
+						},
+					}),
+					Args: graphql.FieldConfigArgument{
+						"last": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						last, _ := p.Args["last"].(string)
+						return source(last), nil
+					},
+				},
+			},
+		}),
+		ResolveMethods: resolveMethods,
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	return schema
+}
+
+func TestExecutesResolveFunction_DefaultFunctionCallsZeroArgMethods(t *testing.T) {
+	schema := methodSchema(t, true, func(last string) interface{} {
+		return &resolveMethodSource{first: "Jane", last: last}
+	})
+
+	expected := map[string]interface{}{
+		"test": map[string]interface{}{
+			"fullName": "Jane Doe",
+			"initials": "JD",
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(last: "Doe") { fullName, initials } }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test { initials } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "name is incomplete" {
+		t.Fatalf("Expected method error, got %v", result.Errors)
+	}
+}
+
+func TestExecutesResolveFunction_DefaultFunctionCallsPointerMethodsOfValues(t *testing.T) {
+	schema := methodSchema(t, true, func(last string) interface{} {
+		return resolveMethodSource{first: "Jane", last: last}
+	})
+
+	expected := map[string]interface{}{
+		"test": map[string]interface{}{
+			"fullName": "Jane Doe",
+			"initials": "JD",
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(last: "Doe") { fullName, initials } }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestExecutesResolveFunction_DefaultFunctionIgnoresMethodsUnlessEnabled(t *testing.T) {
+	schema := methodSchema(t, false, func(last string) interface{} {
+		return &resolveMethodSource{first: "Jane", last: last}
+	})
+
+	expected := map[string]interface{}{
+		"test": map[string]interface{}{
+			"fullName": nil,
+			"initials": nil,
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(last: "Doe") { fullName, initials } }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
@@ -1,6 +1,7 @@
 package graphql_test
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -313,3 +314,181 @@ func TestExecutesUsingAComplexSchema(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestExecutesUsingASchemaWithLazilyLoadedTypes(t *testing.T) {
+	var loaded []string
+	types := map[string]graphql.Type{}
+	load := func(name string) (graphql.Type, error) {
+		loaded = append(loaded, name)
+		return types[name], nil
+	}
+	loadType := func(name string) graphql.Output {
+		ttype, _ := load(name)
+		return ttype
+	}
+
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{"id": &graphql.Field{Type: graphql.ID}}
+		}),
+	})
+	types["Node"] = nodeInterface
+	types["User"] = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			return true
+		},
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.ID},
+				"name": &graphql.Field{Type: graphql.String},
+			}
+		}),
+	})
+	types["Unused"] = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Unused",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				return graphql.Fields{
+					"node": &graphql.Field{
+						Type: loadType("Node"),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return map[string]interface{}{"id": "1", "name": "Luke"}, nil
+						},
+					},
+				}
+			}),
+		}),
+		TypeLoader: load,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Validating the root types only loads the types of their fields.
+	if !reflect.DeepEqual(loaded, []string{"Node"}) {
+		t.Fatalf("Expected only Node to be loaded by NewSchema, got %v", loaded)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ node { id ... on User { name } } __type(name: "User") { interfaces { name } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"node": map[string]interface{}{
+				"id":   "1",
+				"name": "Luke",
+			},
+			"__type": map[string]interface{}{
+				"interfaces": []interface{}{
+					map[string]interface{}{"name": "Node"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	for _, name := range loaded {
+		if name == "Unused" {
+			t.Fatalf("Expected Unused not to be loaded, loaded %v", loaded)
+		}
+	}
+	if _, ok := schema.TypeMap()["User"]; !ok {
+		t.Fatal("Expected User to be in the type map once loaded")
+	}
+}
+
+func TestLazilyLoadedTypesAreValidated(t *testing.T) {
+	errLoad := errors.New("registry unavailable")
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	types := map[string]graphql.Type{
+		"Node": nodeInterface,
+		// User doesn't implement Node.id correctly.
+		"User": graphql.NewObject(graphql.ObjectConfig{
+			Name:       "User",
+			Interfaces: []*graphql.Interface{nodeInterface},
+			Fields: graphql.Fields{
+				"id": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		"Misnamed": graphql.NewObject(graphql.ObjectConfig{
+			Name: "Pet",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{Type: graphql.ID},
+			},
+		}),
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node": &graphql.Field{Type: nodeInterface},
+			},
+		}),
+		TypeLoader: func(name string) (graphql.Type, error) {
+			if name == "Broken" {
+				return nil, errLoad
+			}
+			return types[name], nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := schema.LoadType("Broken"); err != errLoad {
+		t.Fatalf("Expected the loader's error, got %v", err)
+	}
+	if _, err := schema.LoadType("Misnamed"); err == nil || err.Error() != `Type loader returned type "Pet" for type "Misnamed".` {
+		t.Fatalf("Expected a type name mismatch error, got %v", err)
+	}
+	if _, err := schema.LoadType("User"); err == nil || err.Error() != `Node.id expects type "ID!" but User.id provides type "String".` {
+		t.Fatalf("Expected an invalid type error, got %v", err)
+	}
+	if ttype := schema.Type("User"); ttype != nil {
+		t.Fatalf("Expected an invalid type not to be returned, got %v", ttype)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ node { id ... on Broken { id } } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Type "Broken" could not be loaded: registry unavailable` {
+		t.Fatalf("Expected a load error, got %v", result.Errors)
+	}
+}
+
+func TestLazilyLoadedSchemaValidatesRootTypes(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"secret": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		TypeLoader: func(name string) (graphql.Type, error) {
+			return nil, nil
+		},
+		IsVisible: func(kind, name string) bool {
+			return name != "Query"
+		},
+	})
+	if err == nil || err.Error() != `Root type "Query" cannot be hidden.` {
+		t.Fatalf("Expected root type error, got %v", err)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -382,7 +385,7 @@ func TestThreadsSourceCorrectly(t *testing.T) {
 					Type: graphql.String,
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 						resolvedSource = p.Source.(map[string]interface{})
-						return resolvedSource, nil
+						return nil, nil
 					},
 				},
 			},
@@ -496,7 +499,7 @@ func TestCorrectlyThreadsArguments(t *testing.T) {
 					Type: graphql.String,
 					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 						resolvedArgs = p.Args
-						return resolvedArgs, nil
+						return nil, nil
 					},
 				},
 			},
@@ -579,6 +582,54 @@ func TestThreadsRootValueContextCorrectly(t *testing.T) {
 	}
 }
 
+func TestThreadsOperationNameAndRawQuery(t *testing.T) {
+
+	query := `
+      query First { a }
+      query Second { a }
+    `
+
+	var info graphql.ResolveInfo
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						info = p.Info
+						return "b", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// parse query
+	ast := testutil.TestParse(t, query)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           ast,
+		OperationName: "Second",
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+
+	if info.OperationName != "Second" {
+		t.Fatalf("Expected operation name %q, got %q", "Second", info.OperationName)
+	}
+	if info.RawQuery != query {
+		t.Fatalf("Expected raw query %q, got %q", query, info.RawQuery)
+	}
+}
+
 func TestThreadsContextCorrectly(t *testing.T) {
 
 	query := `
@@ -647,6 +698,7 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 					Line: 3, Column: 7,
 				},
 			},
+			Path: []interface{}{"syncError"},
 		},
 	}
 
@@ -697,6 +749,96 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 	}
 }
 
+func TestRecoversFromPanickingResolvers(t *testing.T) {
+	query := `{
+      sync
+      panicError
+      panicValue
+    }`
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.Fields{
+				"sync": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "sync", nil
+					},
+				},
+				"panicError": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						panic(errors.New("resolver exploded"))
+					},
+				},
+				"panicValue": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						panic(42)
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expectedData := map[string]interface{}{
+		"sync":       "sync",
+		"panicError": nil,
+		"panicValue": nil,
+	}
+	expectedErrors := []gqlerrors.FormattedError{
+		{
+			Type:      gqlerrors.ErrorTypeInternal,
+			Message:   "42",
+			Locations: []location.SourceLocation{{Line: 4, Column: 7}},
+			Path:      []interface{}{"panicValue"},
+		},
+		{
+			Type:      gqlerrors.ErrorTypeInternal,
+			Message:   "resolver exploded",
+			Locations: []location.SourceLocation{{Line: 3, Column: 7}},
+			Path:      []interface{}{"panicError"},
+		},
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, query),
+	})
+	if !reflect.DeepEqual(expectedData, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedData, result.Data))
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	sort.Slice(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Message < result.Errors[j].Message
+	})
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+
+	// In debug mode the stack of the panic is exposed in the error extensions.
+	result = testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ sync panicValue }`),
+		Debug:  true,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", result.Errors)
+	}
+	if stack, _ := result.Errors[0].Extensions["stack"].(string); !strings.Contains(stack, "panic") {
+		t.Fatalf("Expected stack trace in extensions, got %#v", result.Errors[0].Extensions)
+	}
+	if result.Data.(map[string]interface{})["sync"] != "sync" {
+		t.Fatalf("Expected sibling field to resolve, got %v", result.Data)
+	}
+}
+
 func TestUsesTheInlineOperationIfNoOperationNameIsProvided(t *testing.T) {
 
 	doc := `{ a }`
@@ -1433,6 +1575,7 @@ func TestFailsWhenAnIsTypeOfCheckIsNotMet(t *testing.T) {
 				Type:      "INTERNAL",
 				Message:   `Expected value of type "SpecialType" but got: graphql_test.testNotSpecialType.`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"specials", 1},
 			},
 		},
 	}
@@ -1877,3 +2020,622 @@ func TestContextDeadline(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
 	}
 }
+
+func TestContextDeadline_PartialResults(t *testing.T) {
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Source.(int)
+					if id == 1 {
+						// The deadline passes while resolving the second item.
+						<-p.Context.Done()
+					}
+					return id, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(itemType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []int{0, 1, 2, 3}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result := graphql.Do(graphql.Params{
+		Schema:                  schema,
+		RequestString:           "{ items { id } }",
+		Context:                 ctx,
+		PartialResultsOnTimeout: true,
+	})
+	timeoutError := func(index int) gqlerrors.FormattedError {
+		return gqlerrors.FormattedError{
+			Message:   context.DeadlineExceeded.Error(),
+			Type:      gqlerrors.ErrorTypeInternal,
+			Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+			Path:      []interface{}{"items", index},
+		}
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": 0},
+				map[string]interface{}{"id": 1},
+				nil,
+				nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{timeoutError(2), timeoutError(3)},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestFieldErrorsIncludeResponsePath(t *testing.T) {
+	friendType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Friend",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := p.Source.(string)
+					if name == "" {
+						return nil, errors.New("unknown name")
+					}
+					return name, nil
+				},
+			},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"friends": &graphql.Field{
+				Type: graphql.NewList(friendType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return []string{"Alice", "Bob", ""}, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return struct{}{}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: "{ user { friends { name } } }",
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{
+				"friends": []interface{}{
+					map[string]interface{}{"name": "Alice"},
+					map[string]interface{}{"name": "Bob"},
+					map[string]interface{}{"name": nil},
+				},
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "unknown name",
+				Type:      gqlerrors.ErrorTypeInternal,
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"user", "friends", 2, "name"},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecuteWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"fast": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "fast", nil
+					},
+				},
+				"slow": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						ch := make(chan string, 1)
+						go func() {
+							<-release
+							ch <- "slow"
+						}()
+						return graphql.Deferred(func(ctx context.Context) (interface{}, error) {
+							select {
+							case v := <-ch:
+								return v, nil
+							case <-ctx.Done():
+								return nil, ctx.Err()
+							}
+						}), nil
+					},
+				},
+				"alsoFast": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "alsoFast", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	result := graphql.ExecuteWithTimeout(graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, "{ fast slow alsoFast }"),
+	}, 50*time.Millisecond)
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"fast":     "fast",
+			"slow":     nil,
+			"alsoFast": "alsoFast",
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   context.DeadlineExceeded.Error(),
+				Type:      gqlerrors.ErrorTypeInternal,
+				Locations: []location.SourceLocation{{Line: 1, Column: 8}},
+				Path:      []interface{}{"slow"},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDeferredFieldsAreCompletedOnceSiblingsAreResolved(t *testing.T) {
+	var resolved []string
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"deferred": &graphql.Field{
+					Type: graphql.NewList(graphql.Int),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						resolved = append(resolved, "deferred")
+						return graphql.Deferred(func(ctx context.Context) (interface{}, error) {
+							resolved = append(resolved, "wait")
+							return []int{1, 2}, nil
+						}), nil
+					},
+				},
+				"failed": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return graphql.Deferred(func(ctx context.Context) (interface{}, error) {
+							return nil, errors.New("failed")
+						}), nil
+					},
+				},
+				"sibling": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						resolved = append(resolved, "sibling")
+						return "sibling", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	// Deferred values are completed the same way whether or not partial
+	// results are returned on timeout.
+	for _, partial := range []bool{false, true} {
+		resolved = nil
+		result := graphql.Execute(graphql.ExecuteParams{
+			Schema:                  schema,
+			AST:                     testutil.TestParse(t, "{ deferred sibling failed }"),
+			PartialResultsOnTimeout: partial,
+		})
+		expected := &graphql.Result{
+			Data: map[string]interface{}{
+				"deferred": []interface{}{1, 2},
+				"sibling":  "sibling",
+				"failed":   nil,
+			},
+			Errors: []gqlerrors.FormattedError{
+				{
+					Message:   "failed",
+					Type:      gqlerrors.ErrorTypeInternal,
+					Locations: []location.SourceLocation{},
+					Path:      []interface{}{"failed"},
+				},
+			},
+		}
+		for i := range result.Errors {
+			result.Errors[i].OriginalError = nil
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+		if resolved[len(resolved)-1] != "wait" {
+			t.Fatalf("Expected the deferred field to be waited for last, got %v", resolved)
+		}
+	}
+}
+
+func TestExecutionLimits(t *testing.T) {
+	nodeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int},
+		},
+	})
+	nodeType.AddFieldConfig("children", &graphql.Field{
+		Type: graphql.NewList(nodeType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return []map[string]interface{}{{"id": 1}, {"id": 2}}, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"root": &graphql.Field{
+					Type: nodeType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"id": 0}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	query := `{ root { children { children { id } } } }`
+
+	// 1 root + 1 children + 2 children + 4 ids
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		MaxFields:     8,
+		MaxDepth:      4,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		MaxFields:     7,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Execution exceeded the maximum of 7 fields.",
+				Type:      gqlerrors.ErrorTypeBadQuery,
+				Locations: []location.SourceLocation{{Line: 1, Column: 32}},
+				Path:      []interface{}{"root", "children", 1, "children", 1, "id"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		MaxDepth:      3,
+	})
+	expected = &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Execution exceeded the maximum depth of 3.",
+				Type:      gqlerrors.ErrorTypeBadQuery,
+				Locations: []location.SourceLocation{{Line: 1, Column: 32}},
+				Path:      []interface{}{"root", "children", 0, "children", 0, "id"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesCyclicFragmentsThatWereNotValidated(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	// Validation rejects the cycle but spreading a fragment that's already been
+	// spread into the same selection set is a no-op during execution.
+	ep := graphql.ExecuteParams{
+		Schema: schema,
+		Root:   map[string]interface{}{"a": "b"},
+		AST:    testutil.TestParse(t, `{ ...A } fragment A on Query { a ...B } fragment B on Query { ...A }`),
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"a": "b"},
+	}
+	result := testutil.TestExecute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutorCoercesRepeatedLiteralsOnce(t *testing.T) {
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"tags":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+			"minAge": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+	var filters []interface{}
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"matches": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filters = append(filters, copyFilter(p.Args["filter"]))
+					// Modifying the arguments doesn't affect other resolvers.
+					filter := p.Args["filter"].(map[string]interface{})
+					filter["minAge"] = 100
+					filter["tags"].([]interface{})[0] = "z"
+					return true, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(itemType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return make([]struct{}, 3), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query ($age: Int) { items { matches(filter: {tags: ["a", "b"], minAge: $age}) } }`,
+		VariableValues: map[string]interface{}{
+			"age": 3,
+		},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"tags":   []interface{}{"a", "b"},
+		"minAge": 3,
+	}
+	if len(filters) != 3 {
+		t.Fatalf("Expected the field to be resolved 3 times, got %d", len(filters))
+	}
+	for _, filter := range filters {
+		if !reflect.DeepEqual(expected, filter) {
+			t.Fatalf("Unexpected filter, Diff: %v", testutil.Diff(expected, filter))
+		}
+	}
+}
+
+// copyFilter returns a copy of a coerced Filter argument.
+func copyFilter(value interface{}) interface{} {
+	filter := value.(map[string]interface{})
+	return map[string]interface{}{
+		"tags":   append([]interface{}(nil), filter["tags"].([]interface{})...),
+		"minAge": filter["minAge"],
+	}
+}
+
+func TestEmitsRawJSONResultsVerbatim(t *testing.T) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.ID},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"cachedUser": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return json.RawMessage(`{"id":"1","name":"Ann","friends":[]}`), nil
+					},
+				},
+				"cachedName": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return json.RawMessage(`"Ann"`), nil
+					},
+				},
+				"missingUser": &graphql.Field{
+					Type: graphql.NewNonNull(userType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return json.RawMessage(`null`), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ cachedUser { name } cachedName }`),
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The raw JSON isn't completed against the selection set.
+	expected := `{"data":{"cachedName":"Ann","cachedUser":{"id":"1","name":"Ann","friends":[]}}}`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, b)
+	}
+
+	result = testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ missingUser { name } }`),
+	})
+	if result.Data != nil || len(result.Errors) != 1 || result.Errors[0].Message != "Cannot return null for non-nullable field Query.missingUser." {
+		t.Fatalf("Expected a non-null error, got %+v", result)
+	}
+}
+
+func TestPendingThunksReturnTheCancellationError(t *testing.T) {
+	// The thunks wait for a batch that's only dispatched after the context is
+	// cancelled, like a data loader collecting keys.
+	dispatch := make(chan struct{})
+	defer close(dispatch)
+	ctx, cancel := context.WithCancel(context.Background())
+	// The context is cancelled once the first thunk is waiting.
+	waiting := make(chan struct{})
+	var waitingOnce sync.Once
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						id := p.Args["id"].(string)
+						if id == "loaded" {
+							return id, nil
+						}
+						return graphql.Deferred(func(ctx context.Context) (interface{}, error) {
+							waitingOnce.Do(func() { close(waiting) })
+							select {
+							case <-dispatch:
+								return id, nil
+							case <-ctx.Done():
+								return nil, ctx.Err()
+							}
+						}), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	go func() {
+		<-waiting
+		cancel()
+	}()
+
+	result := graphql.Do(graphql.Params{
+		Schema:                  schema,
+		RequestString:           `{ a: user(id: "a") b: user(id: "b") loaded: user(id: "loaded") }`,
+		Context:                 ctx,
+		PartialResultsOnTimeout: true,
+	})
+	canceledError := func(column int, path string) gqlerrors.FormattedError {
+		return gqlerrors.FormattedError{
+			Message:   context.Canceled.Error(),
+			Type:      gqlerrors.ErrorTypeInternal,
+			Locations: []location.SourceLocation{{Line: 1, Column: column}},
+			Path:      []interface{}{path},
+		}
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a":      nil,
+			"b":      nil,
+			"loaded": "loaded",
+		},
+		Errors: []gqlerrors.FormattedError{canceledError(3, "a"), canceledError(20, "b")},
+	}
+	// The thunks may be abandoned in any order.
+	sort.Sort(gqlerrors.FormattedErrors(result.Errors))
+	for i := range result.Errors {
+		if !errors.Is(result.Errors[i].OriginalError, context.Canceled) {
+			t.Fatalf("Expected a cancellation error, got %v", result.Errors[i].OriginalError)
+		}
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+type extensionsKey struct{}
+
+// Extensions accumulates the values that resolvers contribute to the
+// `extensions` of a result. It's safe for concurrent use.
+type Extensions struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// ExtensionsFromContext returns the extensions of the request being executed
+// with the context, or nil if there's none (e.g. outside of Execute).
+func ExtensionsFromContext(ctx context.Context) *Extensions {
+	if ctx == nil {
+		return nil
+	}
+	ext, _ := ctx.Value(extensionsKey{}).(*Extensions)
+	return ext
+}
+
+// Set sets the value of the extension with the given key, replacing any previous
+// value. It's a no-op on a nil Extensions so it can be called on the result of
+// ExtensionsFromContext unconditionally.
+func (e *Extensions) Set(key string, value interface{}) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.values == nil {
+		e.values = make(map[string]interface{})
+	}
+	e.values[key] = value
+}
+
+// Get returns the value of the extension with the given key.
+func (e *Extensions) Get(key string) (interface{}, bool) {
+	if e == nil {
+		return nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	v, ok := e.values[key]
+	return v, ok
+}
+
+// Update calls fn with the current value of the extension with the given key
+// (nil if unset) and sets it to the returned value. It allows resolvers to
+// accumulate values, such as counters, without racing each other.
+func (e *Extensions) Update(key string, fn func(value interface{}) interface{}) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.values == nil {
+		e.values = make(map[string]interface{})
+	}
+	e.values[key] = fn(e.values[key])
+}
+
+// merge copies the extensions into the result's extensions.
+func (e *Extensions) merge(r *Result) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.values) == 0 {
+		return
+	}
+	if r.Extensions == nil {
+		r.Extensions = make(map[string]interface{}, len(e.values))
+	}
+	for k, v := range e.values {
+		r.Extensions[k] = v
+	}
+}
@@ -36,6 +36,11 @@ func (g Error) Error() string {
 	return fmt.Sprintf("%v", g.Message)
 }
 
+// Unwrap returns the original error, if any.
+func (g Error) Unwrap() error {
+	return g.OriginalError
+}
+
 // NewError returns a new structured error.
 func NewError(typ ErrorType, message string, nodes []ast.Node, stack string, source *source.Source, positions []int, origError error) *Error {
 	if stack == "" && message != "" {
@@ -57,7 +62,13 @@ func NewError(typ ErrorType, message string, nodes []ast.Node, stack string, sou
 	}
 	locations := []location.SourceLocation{}
 	for _, pos := range positions {
-		loc := location.GetLocation(source, pos)
+		// Locations in concatenated sources are relative to the source
+		// each position comes from, and name it.
+		origin, originPos := source.Origin(pos)
+		loc := location.GetLocation(origin, originPos)
+		if origin != source {
+			loc.Source = sourceName(origin)
+		}
 		locations = append(locations, loc)
 	}
 	return &Error{
@@ -71,3 +82,12 @@ func NewError(typ ErrorType, message string, nodes []ast.Node, stack string, sou
 		OriginalError: origError,
 	}
 }
+
+// sourceName returns the path of the file containing the source, or its name
+// if it's not from a file.
+func sourceName(s *source.Source) string {
+	if s.FilePath() != "" {
+		return s.FilePath()
+	}
+	return s.Name()
+}
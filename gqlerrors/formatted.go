@@ -13,19 +13,47 @@ type FormattedError struct {
 	Type          ErrorType                 `json:"type,omitempty"`
 	UserMessage   string                    `json:"userMessage,omitempty"`
 	Locations     []location.SourceLocation `json:"locations"`
+	Path          []interface{}             `json:"path,omitempty"`
 	StackTrace    string                    `json:"-"`
 	OriginalError error                     `json:"-"`
+	Extensions    map[string]interface{}    `json:"extensions,omitempty"`
 }
 
 func (g FormattedError) Error() string {
 	return g.Message
 }
 
+// Original returns the error that caused this one, if any.
+func (g FormattedError) Original() error {
+	return g.OriginalError
+}
+
+// Unwrap returns the original error so that errors.Is and errors.As can be
+// used to inspect the errors returned by resolvers.
+func (g FormattedError) Unwrap() error {
+	return g.OriginalError
+}
+
 func NewFormattedError(message string) FormattedError {
 	err := errors.New(message)
 	return FormatError(err)
 }
 
+// NewFormattedErrorWithPath returns an error with an explicit response path
+// (field names and list indices) and source locations, for errors that are
+// produced outside of the executor.
+func NewFormattedErrorWithPath(message string, path []interface{}, locations []location.SourceLocation, extensions map[string]interface{}) FormattedError {
+	if locations == nil {
+		locations = []location.SourceLocation{}
+	}
+	return FormattedError{
+		Message:    message,
+		Locations:  locations,
+		Path:       path,
+		Extensions: extensions,
+	}
+}
+
 func FormatError(err error) FormattedError {
 	switch err := err.(type) {
 	case runtime.Error:
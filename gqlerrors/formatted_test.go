@@ -0,0 +1,93 @@
+package gqlerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/location"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+func TestNewFormattedErrorWithPath(t *testing.T) {
+	err := NewFormattedErrorWithPath(
+		"Something went wrong.",
+		[]interface{}{"hero", "friends", 1, "name"},
+		[]location.SourceLocation{{Line: 3, Column: 5}},
+		map[string]interface{}{"code": "NOT_FOUND"},
+	)
+	b, e := json.Marshal(err)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `{"message":"Something went wrong.","locations":[{"line":3,"column":5}],` +
+		`"path":["hero","friends",1,"name"],"extensions":{"code":"NOT_FOUND"}}`
+	if string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, b)
+	}
+
+	b, e = json.Marshal(NewFormattedErrorWithPath("Oops.", nil, nil, nil))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if expected := `{"message":"Oops.","locations":[]}`; string(b) != expected {
+		t.Fatalf("Expected %s, got %s", expected, b)
+	}
+}
+
+type notFoundError struct {
+	id string
+}
+
+func (e *notFoundError) Error() string {
+	return "not found: " + e.id
+}
+
+func TestFormattedErrorUnwrap(t *testing.T) {
+	cause := &notFoundError{id: "123"}
+	err := FormatError(fmt.Errorf("loading user: %w", cause))
+	if err.Message != "loading user: not found: 123" {
+		t.Fatalf("Unexpected message %q", err.Message)
+	}
+	var nf *notFoundError
+	if !errors.As(err, &nf) || nf != cause {
+		t.Fatalf("Expected errors.As to find the original error, got %v", nf)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatal("Expected errors.Is to match the original error")
+	}
+	if errors.Unwrap(NewFormattedError("Oops.")) == nil {
+		t.Fatal("Expected NewFormattedError to keep an original error")
+	}
+	if err := FormatError(&Error{Message: "wrapped", OriginalError: cause}); !errors.Is(err, cause) {
+		t.Fatal("Expected errors.Is to match the original error of an Error")
+	}
+}
+
+func TestFormatErrorNamesTheSourcesOfConcatenatedLocations(t *testing.T) {
+	a := source.New("a.graphql", "type A {\n  b: B\n}")
+	b := source.NewWithLocation("b", "type B {\n  a: Unknown\n}", "schema/b.graphql", source.LocationOffset{})
+	src := source.Concat("schema", a, b)
+	nodes := []ast.Node{
+		&ast.Name{Loc: ast.Location{Start: 14, End: 15, Source: src}},
+		&ast.Name{Loc: ast.Location{Start: 32, End: 39, Source: src}},
+	}
+	err := FormatError(NewError(ErrorTypeBadQuery, `Unknown type "Unknown".`, nodes, "", nil, nil, nil))
+	expected := []location.SourceLocation{
+		{Line: 2, Column: 6, Source: "a.graphql"},
+		{Line: 2, Column: 6, Source: "schema/b.graphql"},
+	}
+	if !reflect.DeepEqual(err.Locations, expected) {
+		t.Fatalf("Expected locations %+v, got %+v", expected, err.Locations)
+	}
+
+	plain := source.New("a.graphql", a.Body())
+	nodes = []ast.Node{&ast.Name{Loc: ast.Location{Start: 14, End: 15, Source: plain}}}
+	err = FormatError(NewError(ErrorTypeBadQuery, `Unknown type "B".`, nodes, "", nil, nil, nil))
+	if expected := []location.SourceLocation{{Line: 2, Column: 6}}; !reflect.DeepEqual(err.Locations, expected) {
+		t.Fatalf("Expected locations %+v, got %+v", expected, err.Locations)
+	}
+}
@@ -29,23 +29,33 @@ func printLine(str string) string {
 }
 
 func NewSyntaxError(s *source.Source, position int, description string) *Error {
-	l := location.GetLocation(s, position)
-	return NewError(
+	// Errors in concatenated sources are reported in the source they come from.
+	origin, originPos := s.Origin(position)
+	l := location.GetLocation(origin, originPos)
+	err := NewError(
 		ErrorTypeSyntax,
-		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", s.Name(), l.Line, l.Column, description, highlightSourceAtLocation(s, l)),
+		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", sourceName(origin), l.Line, l.Column, description, highlightSourceAtLocation(origin, originPos)),
 		[]ast.Node{},
 		"",
-		s,
-		[]int{position},
+		origin,
+		[]int{originPos},
 		nil,
 	)
+	if origin != s {
+		err.Locations[0].Source = sourceName(origin)
+	}
+	return err
 }
 
-func highlightSourceAtLocation(s *source.Source, l location.SourceLocation) string {
+// highlightSourceAtLocation prints the lines of the body around position,
+// numbered relative to the file containing the source.
+func highlightSourceAtLocation(s *source.Source, position int) string {
+	l := location.GetBodyLocation(s, position)
 	line := l.Line
-	prevLineNum := fmt.Sprintf("%d", (line - 1))
-	lineNum := fmt.Sprintf("%d", line)
-	nextLineNum := fmt.Sprintf("%d", (line + 1))
+	lineOffset := s.LocationOffset().Line - 1
+	prevLineNum := fmt.Sprintf("%d", (line + lineOffset - 1))
+	lineNum := fmt.Sprintf("%d", line+lineOffset)
+	nextLineNum := fmt.Sprintf("%d", (line + lineOffset + 1))
 	padLen := len(nextLineNum)
 	lines := regexp.MustCompile("\r\n|[\n\r]").Split(s.Body(), -1)
 	var highlight string
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
@@ -24,6 +25,20 @@ type Params struct {
 	// defined in the requestString.
 	VariableValues map[string]interface{}
 
+	// RawVariableValues is a mapping of variable name to its undecoded JSON value. Each
+	// value is decoded only when the variable is defined by the operation, with numbers
+	// coerced according to the variable's declared type. Entries take precedence over
+	// those in VariableValues.
+	RawVariableValues map[string]json.RawMessage
+
+	// VariableValuesCoerced indicates that VariableValues have already been validated
+	// and coerced to the operation's variable types, so coercion is skipped.
+	VariableValuesCoerced bool
+
+	// LenientBooleans accepts 0/1 and "true"/"false" for Boolean variables, as
+	// sent by some legacy clients, coercing them to bools.
+	LenientBooleans bool
+
 	// OperationName is the name of the operation to use if requestString contains multiple
 	// possible operations. Can be omitted if requestString contains only
 	// one operation.
@@ -32,32 +47,159 @@ type Params struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// Debug includes additional diagnostics, such as the stack trace of a
+	// panicking resolver, in the extensions of returned errors.
+	Debug bool
+
+	// ValidationCache, when set, is used to look up and store the result of validating
+	// the request so that identical requests are only validated once per schema.
+	ValidationCache ValidationCache
+
+	// DocumentCache, when set, is used to look up and store the parsed and
+	// validated request so that identical requests are only parsed and
+	// validated once per schema.
+	DocumentCache DocumentCache
+
+	// PersistedQueryStore, when set, enables automatic persisted queries. A
+	// request with a PersistedQueryHash may omit the RequestString if the query
+	// is in the store, and when it doesn't the query is stored once validated.
+	PersistedQueryStore PersistedQueryStore
+
+	// PersistedQueryHash is the hex encoded SHA-256 hash of the request's query
+	// (i.e. extensions.persistedQuery.sha256Hash of the APQ protocol).
+	PersistedQueryHash string
+
+	// BeforeExecute, when set, is called after the request has been parsed and
+	// validated but before it's executed. It may reject the request by returning
+	// an error or annotate the context passed to resolvers.
+	BeforeExecute BeforeExecuteFn
+
+	// PartialResultsOnTimeout returns the data resolved before the context is
+	// done along with an error for each unresolved field, instead of a single
+	// error. Resolvers must honor the context as execution waits for them.
+	PartialResultsOnTimeout bool
+
+	// MaxFields, when greater than zero, aborts execution with an error once
+	// more than this many fields have been resolved.
+	MaxFields int
+
+	// MaxDepth, when greater than zero, aborts execution with an error when
+	// resolving a field nested deeper than this many fields.
+	MaxDepth int
+
+	// MaxResponseBytes, when greater than zero, limits the size of the JSON
+	// encoded data. The size is counted as values are completed, and execution
+	// is aborted with an error, dropping the data, as soon as it's exceeded.
+	// String escapes aren't counted, so the encoded data may be slightly larger.
+	MaxResponseBytes int
+
+	// RawErrors, when set, also returns the original Go errors behind the
+	// formatted errors in Result.RawErrors so that callers (e.g. internal
+	// tooling) can inspect them with errors.As.
+	RawErrors bool
 }
 
 func Do(p Params) *Result {
-	source := source.New("GraphQL request", p.RequestString)
-	ast, err := parser.Parse(parser.ParseParams{Source: source})
-	if err != nil {
-		return &Result{
-			Errors: gqlerrors.FormatErrors(err),
+	result := do(p)
+	if p.RawErrors {
+		result.RawErrors = rawErrors(result.Errors)
+	}
+	return result
+}
+
+// rawErrors returns the original error of each formatted error, or the
+// formatted error itself if it didn't wrap one.
+func rawErrors(errs []gqlerrors.FormattedError) []error {
+	if len(errs) == 0 {
+		return nil
+	}
+	raw := make([]error, len(errs))
+	for i, err := range errs {
+		if err.OriginalError != nil {
+			raw[i] = err.OriginalError
+		} else {
+			raw[i] = err
+		}
+	}
+	return raw
+}
+
+func do(p Params) *Result {
+	requestString, persistedQueryHash, result := persistedQuery(&p)
+	if result != nil {
+		return result
+	}
+	p.RequestString = requestString
+
+	var doc *CachedDocument
+	var documentKey string
+	if p.DocumentCache != nil {
+		documentKey = validationCacheKey(&p.Schema, p.RequestString)
+		doc, _ = p.DocumentCache.Get(documentKey)
+	}
+	if doc == nil {
+		source := source.New("GraphQL request", p.RequestString)
+		ast, err := parser.Parse(parser.ParseParams{Source: source})
+		if err != nil {
+			return &Result{
+				Errors: gqlerrors.FormatErrors(err),
+			}
+		}
+		var validationResult ValidationResult
+		if p.ValidationCache != nil {
+			key := validationCacheKey(&p.Schema, p.RequestString)
+			var ok bool
+			if validationResult, ok = p.ValidationCache.Get(key); !ok {
+				validationResult = ValidateDocument(&p.Schema, ast, nil)
+				p.ValidationCache.Set(key, validationResult)
+			}
+		} else {
+			validationResult = ValidateDocument(&p.Schema, ast, nil)
+		}
+		doc = &CachedDocument{AST: ast, Validation: validationResult}
+		if p.DocumentCache != nil {
+			p.DocumentCache.Set(documentKey, doc)
 		}
 	}
-	validationResult := ValidateDocument(&p.Schema, ast, nil)
 
-	if !validationResult.IsValid {
+	if !doc.Validation.IsValid {
 		return &Result{
-			Errors: validationResult.Errors,
+			Errors: doc.Validation.Errors,
+		}
+	}
+	if persistedQueryHash != "" {
+		p.PersistedQueryStore.Set(persistedQueryHash, p.RequestString)
+	}
+
+	args := p.VariableValues
+	if len(p.RawVariableValues) != 0 {
+		args = make(map[string]interface{}, len(p.VariableValues)+len(p.RawVariableValues))
+		for name, value := range p.VariableValues {
+			args[name] = value
+		}
+		for name, value := range p.RawVariableValues {
+			args[name] = value
 		}
 	}
 
-	return Execute(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           ast,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
+	result = Execute(ExecuteParams{
+		Schema:                  p.Schema,
+		Root:                    p.RootObject,
+		AST:                     doc.AST,
+		OperationName:           p.OperationName,
+		Args:                    args,
+		Context:                 p.Context,
+		Debug:                   p.Debug,
+		ArgsCoerced:             p.VariableValuesCoerced,
+		LenientBooleans:         p.LenientBooleans,
+		BeforeExecute:           p.BeforeExecute,
+		PartialResultsOnTimeout: p.PartialResultsOnTimeout,
+		MaxFields:               p.MaxFields,
+		MaxDepth:                p.MaxDepth,
+		MaxResponseBytes:        p.MaxResponseBytes,
 	})
+	return result
 }
 
 // RequestTypeNames rewrites an ast document to include __typename
@@ -1,12 +1,20 @@
 package graphql_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
 	"context"
 
 	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/location"
 	"github.com/sprucehealth/graphql/testutil"
 )
 
@@ -220,3 +228,530 @@ func TestEmptyStringIsNotNull(t *testing.T) {
 		t.Errorf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
 	}
 }
+
+func TestMaxResponseBytes(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						items := make([]string, 1000)
+						for i := range items {
+							items[i] = "item"
+						}
+						return items, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+	query := `{ items }`
+
+	result := graphql.Do(graphql.Params{
+		Schema:           schema,
+		RequestString:    query,
+		MaxResponseBytes: 100,
+	})
+	if result.Data != nil {
+		t.Fatalf("Expected data to be dropped, got %v", result.Data)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != "Response exceeds the maximum size of 100 bytes." {
+		t.Fatalf("Expected response size error, got %v", result.Errors)
+	}
+
+	// A response that exactly fits the limit is left untouched.
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result = graphql.Do(graphql.Params{
+		Schema:           schema,
+		RequestString:    query,
+		MaxResponseBytes: len(b),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if items, _ := result.Data.(map[string]interface{})["items"].([]interface{}); len(items) != 1000 {
+		t.Fatalf("Expected 1000 items, got %d", len(items))
+	}
+}
+
+func TestMaxResponseBytes_StopsResolvingOnceExceeded(t *testing.T) {
+	var resolved int
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					resolved++
+					return "item", nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(itemType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return make([]struct{}, 1000), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:           schema,
+		RequestString:    `{ items { name } }`,
+		MaxResponseBytes: 100,
+	})
+	if result.Data != nil {
+		t.Fatalf("Expected data to be dropped, got %v", result.Data)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != "Response exceeds the maximum size of 100 bytes." {
+		t.Fatalf("Expected response size error, got %v", result.Errors)
+	}
+	if resolved >= 10 {
+		t.Fatalf("Expected execution to stop once the limit was exceeded, resolved %d items", resolved)
+	}
+}
+
+func TestDoSelectsOperationByName(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "a", nil
+					},
+				},
+				"b": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "b", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+	query := `
+		query First { a }
+		query Second { b }
+	`
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		OperationName: "Second",
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"b": "b",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if result.Data != nil || len(result.Errors) != 1 ||
+		result.Errors[0].Message != "Must provide operation name if query contains multiple operations." {
+		t.Fatalf("Expected ambiguous operation error, got %v", result)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		OperationName: "Third",
+	})
+	if result.Data != nil || len(result.Errors) != 1 || result.Errors[0].Message != `Unknown operation named "Third".` {
+		t.Fatalf("Expected unknown operation error, got %v", result)
+	}
+}
+
+type countingValidationCache struct {
+	results    map[string]graphql.ValidationResult
+	hits, sets int
+}
+
+func (c *countingValidationCache) Get(key string) (graphql.ValidationResult, bool) {
+	vr, ok := c.results[key]
+	if ok {
+		c.hits++
+	}
+	return vr, ok
+}
+
+func (c *countingValidationCache) Set(key string, vr graphql.ValidationResult) {
+	c.sets++
+	c.results[key] = vr
+}
+
+func TestDoUsesValidationCache(t *testing.T) {
+	cache := &countingValidationCache{results: make(map[string]graphql.ValidationResult)}
+	query := `query HeroNameQuery { hero { name } }`
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:          testutil.StarWarsSchema,
+			RequestString:   query,
+			ValidationCache: cache,
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+	}
+	if cache.sets != 1 || cache.hits != 2 {
+		t.Fatalf("Expected 1 validation and 2 cache hits, got %d and %d", cache.sets, cache.hits)
+	}
+
+	// Invalid documents are cached as well
+	for i := 0; i < 2; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:          testutil.StarWarsSchema,
+			RequestString:   `{ hero { unknownField } }`,
+			ValidationCache: cache,
+		})
+		if len(result.Errors) != 1 {
+			t.Fatalf("Expected a validation error, got %v", result.Errors)
+		}
+	}
+	if cache.sets != 2 || cache.hits != 3 {
+		t.Fatalf("Expected 2 validations and 3 cache hits, got %d and %d", cache.sets, cache.hits)
+	}
+
+	// A different schema doesn't share cached results
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hero": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:          schema,
+		RequestString:   query,
+		ValidationCache: cache,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected a validation error, got %v", result.Errors)
+	}
+	if cache.sets != 3 {
+		t.Fatalf("Expected 3 validations, got %d", cache.sets)
+	}
+}
+
+func TestDoUsesDocumentCache(t *testing.T) {
+	var operations []ast.Definition
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			operations = append(operations, p.Info.Operation)
+			return "ok", nil
+		},
+	})
+	cache := graphql.NewLRUDocumentCache(1)
+	for i := 0; i < 2; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ test }`,
+			DocumentCache: cache,
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+	}
+	// The request is only parsed once so both executions share its AST.
+	if len(operations) != 2 || operations[0] != operations[1] {
+		t.Fatalf("Expected both requests to execute the same operation, got %v", operations)
+	}
+
+	// Invalid documents are cached as well, evicting the least recently used.
+	for i := 0; i < 2; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ unknownField }`,
+			DocumentCache: cache,
+		})
+		if len(result.Errors) != 1 {
+			t.Fatalf("Expected a validation error, got %v", result.Errors)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("Expected 1 cached document, got %d", cache.Len())
+	}
+	graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		DocumentCache: cache,
+	})
+	if len(operations) != 3 || operations[2] == operations[0] {
+		t.Fatal("Expected the evicted request to be parsed again")
+	}
+}
+
+type mapPersistedQueryStore map[string]string
+
+func (s mapPersistedQueryStore) Get(hash string) (string, bool) {
+	query, ok := s[hash]
+	return query, ok
+}
+
+func (s mapPersistedQueryStore) Set(hash string, query string) {
+	s[hash] = query
+}
+
+func TestDoRegistersAndExecutesPersistedQueries(t *testing.T) {
+	store := mapPersistedQueryStore{}
+	query := `query HeroNameQuery { hero { name } }`
+	sum := sha256.Sum256([]byte(query))
+	hash := hex.EncodeToString(sum[:])
+
+	// The client first sends only the hash which isn't known yet.
+	result := graphql.Do(graphql.Params{
+		Schema:              testutil.StarWarsSchema,
+		PersistedQueryStore: store,
+		PersistedQueryHash:  hash,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:    "PersistedQueryNotFound",
+				Type:       gqlerrors.ErrorTypeBadQuery,
+				Locations:  []location.SourceLocation{},
+				Extensions: map[string]interface{}{"code": graphql.ErrorCodePersistedQueryNotFound},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// It then sends the query along with the hash to register it.
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+	result = graphql.Do(graphql.Params{
+		Schema:              testutil.StarWarsSchema,
+		RequestString:       query,
+		PersistedQueryStore: store,
+		PersistedQueryHash:  hash,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if store[hash] != query {
+		t.Fatalf("Expected the query to be stored, got %v", store)
+	}
+
+	// After which the hash is enough.
+	result = graphql.Do(graphql.Params{
+		Schema:              testutil.StarWarsSchema,
+		PersistedQueryStore: store,
+		PersistedQueryHash:  hash,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDoRejectsPersistedQueriesWithMismatchedHash(t *testing.T) {
+	store := mapPersistedQueryStore{}
+	sum := sha256.Sum256([]byte(`{ hero { name } }`))
+	hash := hex.EncodeToString(sum[:])
+	result := graphql.Do(graphql.Params{
+		Schema:              testutil.StarWarsSchema,
+		RequestString:       `{ hero { id } }`,
+		PersistedQueryStore: store,
+		PersistedQueryHash:  hash,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "provided sha does not match query",
+				Type:      gqlerrors.ErrorTypeBadQuery,
+				Locations: []location.SourceLocation{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if len(store) != 0 {
+		t.Fatalf("Expected nothing to be stored, got %v", store)
+	}
+}
+
+func TestBeforeExecute(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Context.Value("operation"), nil
+		},
+	})
+	beforeExecute := func(ctx context.Context, op *ast.OperationDefinition, vars map[string]interface{}) (context.Context, error) {
+		if op.Name != nil && op.Name.Value == "Forbidden" {
+			return nil, errors.New("operation Forbidden is not allowed")
+		}
+		return context.WithValue(ctx, "operation", op.Name.Value), nil
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query Allowed { test }`,
+		BeforeExecute: beforeExecute,
+	})
+	expected := &graphql.Result{Data: map[string]interface{}{"test": "Allowed"}}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query Forbidden { test }`,
+		BeforeExecute: beforeExecute,
+	})
+	if result.Data != nil || len(result.Errors) != 1 || result.Errors[0].Message != "operation Forbidden is not allowed" {
+		t.Fatalf("Expected the request to be rejected, got %+v", result)
+	}
+}
+
+func TestResolverErrorsCanBeUnwrapped(t *testing.T) {
+	errNotFound := errors.New("not found")
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return nil, fmt.Errorf("loading test: %w", errNotFound)
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected one error, got %v", result.Errors)
+	}
+	if !errors.Is(result.Errors[0], errNotFound) {
+		t.Fatalf("Expected the resolver's error to be wrapped, got %#v", result.Errors[0])
+	}
+}
+
+type permissionError struct {
+	permission string
+}
+
+func (e *permissionError) Error() string {
+	return "missing permission " + e.permission
+}
+
+func TestDoReturnsRawErrors(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return nil, fmt.Errorf("loading test: %w", &permissionError{permission: "admin"})
+		},
+	})
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	if result.RawErrors != nil {
+		t.Fatalf("Expected no raw errors unless requested, got %v", result.RawErrors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		RawErrors:     true,
+	})
+	if len(result.Errors) != 1 || len(result.RawErrors) != 1 {
+		t.Fatalf("Expected one error, got %v and raw %v", result.Errors, result.RawErrors)
+	}
+	var permErr *permissionError
+	if !errors.As(result.RawErrors[0], &permErr) || permErr.permission != "admin" {
+		t.Fatalf("Expected the resolver's typed error, got %#v", result.RawErrors[0])
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encoded map[string]interface{}
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := encoded["RawErrors"]; ok {
+		t.Fatalf("Expected raw errors not to be encoded, got %s", b)
+	}
+
+	// Errors that don't wrap an original error are returned as is.
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ unknown }`,
+		RawErrors:     true,
+	})
+	if len(result.RawErrors) != 1 || result.RawErrors[0].Error() != result.Errors[0].Message {
+		t.Fatalf("Expected the validation error, got %v", result.RawErrors)
+	}
+}
+
+func TestResolversContributeExtensions(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			ext := graphql.ExtensionsFromContext(p.Context)
+			ext.Set("rateLimit", map[string]interface{}{"remaining": 99})
+			ext.Update("calls", func(v interface{}) interface{} {
+				n, _ := v.(int)
+				return n + 1
+			})
+			return "ok", nil
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a: test b: test }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"a": "ok", "b": "ok"},
+		Extensions: map[string]interface{}{
+			"rateLimit": map[string]interface{}{"remaining": 99},
+			"calls":     2,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
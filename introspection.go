@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strconv"
@@ -256,21 +257,10 @@ func init() {
 					"input value.",
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if inputVal, ok := p.Source.(*Argument); ok {
-						if inputVal.DefaultValue == nil {
-							return nil, nil
-						}
-						if isNullish(inputVal.DefaultValue) {
-							return nil, nil
-						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
-						return printer.Print(astVal), nil
+						return printDefaultValue(inputVal.DefaultValue, inputVal.Type), nil
 					}
 					if inputVal, ok := p.Source.(*InputObjectField); ok {
-						if inputVal.DefaultValue == nil {
-							return nil, nil
-						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
-						return printer.Print(astVal), nil
+						return printDefaultValue(inputVal.DefaultValue, inputVal.Type), nil
 					}
 					return nil, nil
 				},
@@ -415,8 +405,10 @@ func init() {
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if schema, ok := p.Source.(Schema); ok {
 						var results []Type
-						for _, ttype := range schema.TypeMap() {
-							results = append(results, ttype)
+						for name, ttype := range schema.TypeMap() {
+							if schema.typeVisible(name) {
+								results = append(results, ttype)
+							}
 						}
 						sort.Slice(results, func(i, j int) bool {
 							return results[i].Name() < results[j].Name()
@@ -426,6 +418,16 @@ func init() {
 					return []Type{}, nil
 				},
 			},
+			"description": &Field{
+				Description: "A description of the schema.",
+				Type:        String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if schema, ok := p.Source.(Schema); ok && schema.Description() != "" {
+						return schema.Description(), nil
+					}
+					return nil, nil
+				},
+			},
 			"queryType": &Field{
 				Description: "The type that query operations will be rooted at.",
 				Type:        NewNonNull(TypeType),
@@ -526,6 +528,9 @@ func init() {
 					if !includeDeprecated && field.DeprecationReason != "" {
 						continue
 					}
+					if !p.Info.Schema.fieldVisible(ttype.Name(), field.Name) {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				sort.Slice(fields, func(i, j int) bool {
@@ -541,6 +546,9 @@ func init() {
 					if !includeDeprecated && field.DeprecationReason != "" {
 						continue
 					}
+					if !p.Info.Schema.fieldVisible(ttype.Name(), field.Name) {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				sort.Slice(fields, func(i, j int) bool {
@@ -556,7 +564,9 @@ func init() {
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			switch ttype := p.Source.(type) {
 			case *Object:
-				return ttype.Interfaces(), nil
+				return visibleInterfaces(p.Info.Schema, ttype.Interfaces()), nil
+			case *Interface:
+				return visibleInterfaces(p.Info.Schema, ttype.Interfaces()), nil
 			}
 			return nil, nil
 		},
@@ -566,9 +576,9 @@ func init() {
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			switch ttype := p.Source.(type) {
 			case *Interface:
-				return p.Info.Schema.PossibleTypes(ttype), nil
+				return visibleObjects(p.Info.Schema, p.Info.Schema.PossibleTypes(ttype)), nil
 			case *Union:
-				return p.Info.Schema.PossibleTypes(ttype), nil
+				return visibleObjects(p.Info.Schema, p.Info.Schema.PossibleTypes(ttype)), nil
 			}
 			return nil, nil
 		},
@@ -623,6 +633,24 @@ func init() {
 	TypeType.AddFieldConfig("ofType", &Field{
 		Type: TypeType,
 	})
+	TypeType.AddFieldConfig("specifiedByURL", &Field{
+		Type: String,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			if ttype, ok := p.Source.(*Scalar); ok && ttype.SpecifiedByURL() != "" {
+				return ttype.SpecifiedByURL(), nil
+			}
+			return nil, nil
+		},
+	})
+	TypeType.AddFieldConfig("isOneOf", &Field{
+		Type: Boolean,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			if ttype, ok := p.Source.(*InputObject); ok {
+				return ttype.IsOneOf(), nil
+			}
+			return nil, nil
+		},
+	})
 
 	// Note that these are FieldDefinition and not FieldConfig,
 	// so the format for args is different.
@@ -647,10 +675,10 @@ func init() {
 		},
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			name, ok := p.Args["name"].(string)
-			if !ok {
+			if !ok || !p.Info.Schema.typeVisible(name) {
 				return nil, nil
 			}
-			return p.Info.Schema.Type(name), nil
+			return p.Info.Schema.LoadType(name)
 		},
 	}
 
@@ -666,105 +694,178 @@ func init() {
 
 }
 
-// Produces a GraphQL Value AST given a Golang value.
-//
-// Optionally, a GraphQL type may be provided, which will be used to
-// disambiguate between value primitives.
+// visibleInterfaces returns the interfaces that are visible to clients of the
+// schema.
+func visibleInterfaces(schema Schema, interfaces []*Interface) []*Interface {
+	visible := make([]*Interface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if schema.typeVisible(iface.Name()) {
+			visible = append(visible, iface)
+		}
+	}
+	return visible
+}
+
+// visibleObjects returns the object types that are visible to clients of the
+// schema.
+func visibleObjects(schema Schema, objects []*Object) []*Object {
+	visible := make([]*Object, 0, len(objects))
+	for _, object := range objects {
+		if schema.typeVisible(object.Name()) {
+			visible = append(visible, object)
+		}
+	}
+	return visible
+}
+
+// printDefaultValue returns the default value as a GraphQL literal, or nil if
+// there's none.
+func printDefaultValue(value interface{}, ttype Input) interface{} {
+	astVal := AstFromValue(value, ttype)
+	if astVal == nil {
+		return nil
+	}
+	return printer.Print(astVal)
+}
+
+// AstFromValue produces a GraphQL Value AST given a Golang value of the input
+// type, e.g. to print default values. It's the inverse of ValueFromAST. Leaf
+// values are serialized by their type, lists are given as slices or arrays,
+// and input objects as maps with string keys. It returns nil for null values
+// and values that can't be serialized.
 //
-// | JSON Value    | GraphQL Value        |
+// | Golang Value  | GraphQL Value        |
 // | ------------- | -------------------- |
-// | Object        | Input Object         |
-// | Array         | List                 |
+// | Map           | Input Object         |
+// | Slice / Array | List                 |
 // | Boolean       | Boolean              |
 // | String        | String / Enum Value  |
 // | Number        | Int / Float          |
-
-func astFromValue(value interface{}, ttype Type) ast.Value {
+func AstFromValue(value interface{}, ttype Type) ast.Value {
 	if ttype, ok := ttype.(*NonNull); ok {
 		// Note: we're not checking that the result is non-null.
 		// This function is not responsible for validating the input value.
-		val := astFromValue(value, ttype.OfType)
-		return val
+		return AstFromValue(value, ttype.OfType)
 	}
 	if isNullish(value) {
 		return nil
 	}
 	valueVal := reflect.ValueOf(value)
-	if !valueVal.IsValid() {
-		return nil
-	}
-	if valueVal.Type().Kind() == reflect.Ptr {
+	for valueVal.Kind() == reflect.Ptr || valueVal.Kind() == reflect.Interface {
+		if valueVal.IsNil() {
+			return nil
+		}
 		valueVal = valueVal.Elem()
 	}
-	if !valueVal.IsValid() {
-		return nil
-	}
 
-	// Convert Golang slice to GraphQL list. If the Type is a list, but
-	// the value is not an array, convert the value using the list's item type.
-	if ttype, ok := ttype.(*List); ok {
-		if valueVal.Type().Kind() == reflect.Slice {
-			itemType := ttype.OfType
-			var values []ast.Value
-			for i := 0; i < valueVal.Len(); i++ {
-				item := valueVal.Index(i).Interface()
-				itemAST := astFromValue(item, itemType)
-				if itemAST != nil {
-					values = append(values, itemAST)
-				}
-			}
-			return &ast.ListValue{
-				Values: values,
-			}
-		}
+	switch ttype := ttype.(type) {
+	case *List:
 		// Because GraphQL will accept single values as a "list of one" when
 		// expecting a list, if there's a non-array value and an expected list type,
 		// create an AST using the list's item type.
-		val := astFromValue(value, ttype.OfType)
-		return val
-	}
-
-	if valueVal.Type().Kind() == reflect.Map {
-		// TODO: implement astFromValue from Map to Value
+		if valueVal.Kind() != reflect.Slice && valueVal.Kind() != reflect.Array {
+			return AstFromValue(value, ttype.OfType)
+		}
+		values := make([]ast.Value, 0, valueVal.Len())
+		for i := 0; i < valueVal.Len(); i++ {
+			if itemAST := AstFromValue(valueVal.Index(i).Interface(), ttype.OfType); itemAST != nil {
+				values = append(values, itemAST)
+			}
+		}
+		return &ast.ListValue{
+			Values: values,
+		}
+	case *InputObject:
+		if valueVal.Kind() != reflect.Map || valueVal.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		fieldMap := ttype.Fields()
+		names := make([]string, 0, len(fieldMap))
+		for name := range fieldMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var fields []*ast.ObjectField
+		for _, name := range names {
+			fieldVal := valueVal.MapIndex(reflect.ValueOf(name).Convert(valueVal.Type().Key()))
+			if !fieldVal.IsValid() {
+				continue
+			}
+			if fieldAST := AstFromValue(fieldVal.Interface(), fieldMap[name].Type); fieldAST != nil {
+				fields = append(fields, &ast.ObjectField{
+					Name:  &ast.Name{Value: name},
+					Value: fieldAST,
+				})
+			}
+		}
+		return &ast.ObjectValue{
+			Fields: fields,
+		}
+	case *Enum:
+		name, ok := ttype.Serialize(value).(string)
+		if !ok {
+			return nil
+		}
+		return &ast.EnumValue{
+			Value: name,
+		}
+	case *Scalar:
+		serialized := ttype.Serialize(value)
+		if isNullish(serialized) {
+			return nil
+		}
+		return astFromSerializedValue(serialized, ttype == ID)
 	}
+	return astFromSerializedValue(valueVal.Interface(), false)
+}
 
-	if value, ok := value.(bool); ok {
+// astFromSerializedValue produces the literal of a serialized leaf value. IDs
+// that look like integers are given as Int literals.
+func astFromSerializedValue(value interface{}, isID bool) ast.Value {
+	switch value := value.(type) {
+	case bool:
 		return &ast.BooleanValue{
 			Value: value,
 		}
-	}
-	if value, ok := value.(int); ok {
-		if ttype == Float {
-			return &ast.IntValue{
-				Value: strconv.Itoa(value) + ".0",
+	case string:
+		if isID {
+			if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return &ast.IntValue{
+					Value: value,
+				}
 			}
 		}
-		return &ast.IntValue{
-			Value: strconv.Itoa(value),
+		return &ast.StringValue{
+			Value: value,
 		}
-	}
-	if value, ok := value.(float32); ok {
-		return &ast.FloatValue{
-			Value: strconv.FormatFloat(float64(value), 'f', -1, 32),
+	case float32:
+		return astFromSerializedValue(float64(value), isID)
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil
+		}
+		if value == math.Trunc(value) && math.Abs(value) < 1e21 {
+			return &ast.IntValue{
+				Value: strconv.FormatFloat(value, 'f', -1, 64),
+			}
 		}
-	}
-	if value, ok := value.(float64); ok {
 		return &ast.FloatValue{
 			Value: strconv.FormatFloat(value, 'f', -1, 64),
 		}
 	}
-
-	if value, ok := value.(string); ok {
-		if _, ok := ttype.(*Enum); ok {
-			return &ast.EnumValue{
-				Value: value,
-			}
+	valueVal := reflect.ValueOf(value)
+	switch valueVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &ast.IntValue{
+			Value: strconv.FormatInt(valueVal.Int(), 10),
 		}
-		return &ast.StringValue{
-			Value: value,
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &ast.IntValue{
+			Value: strconv.FormatUint(valueVal.Uint(), 10),
 		}
+	case reflect.String:
+		return astFromSerializedValue(valueVal.String(), isID)
 	}
-
 	// fallback, treat as string
 	return &ast.StringValue{
 		Value: fmt.Sprintf("%v", value),
@@ -0,0 +1,19 @@
+package graphql
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestIntrospectionCache_IsBounded(t *testing.T) {
+	c := &introspectionCache{entries: make(map[string]interface{})}
+	for i := 0; i < 2*maxIntrospectionCacheEntries; i++ {
+		c.store(strconv.Itoa(i), map[string]interface{}{"i": i})
+	}
+	if len(c.entries) != maxIntrospectionCacheEntries {
+		t.Fatalf("Expected %d cached entries, got %d", maxIntrospectionCacheEntries, len(c.entries))
+	}
+	if _, ok := c.load(strconv.Itoa(maxIntrospectionCacheEntries)); ok {
+		t.Fatal("Expected entries stored once the cache is full to not be cached")
+	}
+}
@@ -1,7 +1,14 @@
 package graphql_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
@@ -1472,3 +1479,727 @@ func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestIntrospection_ExposesDescriptionsOnCustomScalars(t *testing.T) {
+	dateScalar := graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "Date",
+		Description: "A calendar date formatted as YYYY-MM-DD.",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+	})
+	queryRoot := graphql.NewObject(graphql.ObjectConfig{
+		Name: "QueryRoot",
+		Fields: graphql.Fields{
+			"today": &graphql.Field{
+				Type: dateScalar,
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryRoot,
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        dateType: __type(name: "Date") {
+          kind,
+          name,
+          description
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"dateType": map[string]interface{}{
+				"kind":        "SCALAR",
+				"name":        "Date",
+				"description": "A calendar date formatted as YYYY-MM-DD.",
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_RepeatedMixedQueriesReturnConsistentResults(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "QueryRoot",
+			Fields: graphql.Fields{
+				"value": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Context.Value("value"), nil
+					},
+				},
+				"old": &graphql.Field{
+					Type:              graphql.String,
+					DeprecationReason: "Use value",
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+
+	query := `
+      query Mixed($deprecated: Boolean!) {
+        value
+        __schema { queryType { ...QueryFields } }
+        withVariable: __schema { queryType { fields(includeDeprecated: $deprecated) { name } } }
+      }
+      fragment QueryFields on __Type { name fields { name } }
+    `
+	for i, deprecated := range []bool{true, false, true} {
+		result := g(t, graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: map[string]interface{}{"deprecated": deprecated},
+			Context:        context.WithValue(context.Background(), "value", i),
+		})
+		withVariableFields := []interface{}{
+			map[string]interface{}{"name": "value"},
+		}
+		if deprecated {
+			withVariableFields = append([]interface{}{map[string]interface{}{"name": "old"}}, withVariableFields...)
+		}
+		expected := &graphql.Result{
+			Data: map[string]interface{}{
+				"value": i,
+				"__schema": map[string]interface{}{
+					"queryType": map[string]interface{}{
+						"name": "QueryRoot",
+						"fields": []interface{}{
+							map[string]interface{}{"name": "value"},
+						},
+					},
+				},
+				"withVariable": map[string]interface{}{
+					"queryType": map[string]interface{}{
+						"fields": withVariableFields,
+					},
+				},
+			},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result for run %d, Diff: %v", i, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func BenchmarkIntrospection_MixedQuery(b *testing.B) {
+	query := strings.Replace(testutil.IntrospectionQuery, "__schema {", "hero { name }\n    __schema {", 1)
+
+	b.Run("cold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: testutil.StarWarsSchema.QueryType(),
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.StartTimer()
+			if r := graphql.Do(graphql.Params{Schema: schema, RequestString: query}); len(r.Errors) != 0 {
+				b.Fatal(r.Errors)
+			}
+		}
+	})
+	b.Run("repeated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if r := graphql.Do(graphql.Params{Schema: testutil.StarWarsSchema, RequestString: query}); len(r.Errors) != 0 {
+				b.Fatal(r.Errors)
+			}
+		}
+	})
+}
+
+func TestIntrospection_ExposesSchemaDescription(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Description: "The Star Wars API",
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "QueryRoot",
+			Fields: graphql.Fields{
+				"onlyField": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	sdlSchema, err := graphql.BuildSchema(`
+# The Star Wars API
+schema {
+  query: QueryRoot
+}
+
+type QueryRoot {
+  onlyField: String
+}
+`)
+	if err != nil {
+		t.Fatalf("Error building Schema: %v", err.Error())
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"description": "The Star Wars API",
+			},
+		},
+	}
+	for _, schema := range []graphql.Schema{schema, sdlSchema} {
+		result := g(t, graphql.Params{
+			Schema:        schema,
+			RequestString: `{ __schema { description } }`,
+		})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestIntrospection_ExposesIsOneOfOnInputObjects(t *testing.T) {
+	newInput := func(name string, isOneOf bool) *graphql.InputObject {
+		return graphql.NewInputObject(graphql.InputObjectConfig{
+			Name:    name,
+			IsOneOf: isOneOf,
+			Fields: graphql.InputObjectConfigFieldMap{
+				"id":    &graphql.InputObjectFieldConfig{Type: graphql.ID},
+				"email": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			},
+		})
+	}
+	queryRoot := graphql.NewObject(graphql.ObjectConfig{
+		Name: "QueryRoot",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"by":     &graphql.ArgumentConfig{Type: newInput("UserBy", true)},
+					"filter": &graphql.ArgumentConfig{Type: newInput("UserFilter", false)},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryRoot,
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        userBy: __type(name: "UserBy") { isOneOf }
+        userFilter: __type(name: "UserFilter") { isOneOf }
+        queryRoot: __type(name: "QueryRoot") { isOneOf }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"userBy":     map[string]interface{}{"isOneOf": true},
+			"userFilter": map[string]interface{}{"isOneOf": false},
+			"queryRoot":  map[string]interface{}{"isOneOf": nil},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_ExposesSpecifiedByURLOfScalars(t *testing.T) {
+	uuidType := graphql.NewScalar(graphql.ScalarConfig{
+		Name:           "UUID",
+		Serialize:      func(value interface{}) interface{} { return value },
+		SpecifiedByURL: "https://tools.ietf.org/html/rfc4122",
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "QueryRoot",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{Type: uuidType},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	sdlSchema, err := graphql.BuildSchema(`
+		scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+		type QueryRoot { id: UUID }
+		schema { query: QueryRoot }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := `
+      {
+        uuid: __type(name: "UUID") { specifiedByURL }
+        string: __type(name: "String") { specifiedByURL }
+        queryRoot: __type(name: "QueryRoot") { specifiedByURL }
+        __schema {
+          directives {
+            name
+            locations
+            args { name }
+          }
+        }
+      }
+    `
+	for _, schema := range []graphql.Schema{schema, sdlSchema} {
+		result := g(t, graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+		data := result.Data.(map[string]interface{})
+		expected := map[string]interface{}{
+			"uuid":      map[string]interface{}{"specifiedByURL": "https://tools.ietf.org/html/rfc4122"},
+			"string":    map[string]interface{}{"specifiedByURL": nil},
+			"queryRoot": map[string]interface{}{"specifiedByURL": nil},
+		}
+		for k, v := range expected {
+			if !reflect.DeepEqual(v, data[k]) {
+				t.Fatalf("Unexpected %s, Diff: %v", k, testutil.Diff(v, data[k]))
+			}
+		}
+		expectedDirective := map[string]interface{}{
+			"name":      "specifiedBy",
+			"locations": []interface{}{"SCALAR"},
+			"args":      []interface{}{map[string]interface{}{"name": "url"}},
+		}
+		var found bool
+		for _, d := range data["__schema"].(map[string]interface{})["directives"].([]interface{}) {
+			if reflect.DeepEqual(expectedDirective, d) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("Expected the specifiedBy directive in %v", data["__schema"])
+		}
+	}
+}
+
+func TestIntrospection_PrintsInputObjectDefaultValues(t *testing.T) {
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":  &graphql.EnumValueConfig{Value: 0},
+			"BLUE": &graphql.EnumValueConfig{Value: 1},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":     &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"name":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"colors": &graphql.InputObjectFieldConfig{Type: graphql.NewList(colorType), DefaultValue: []interface{}{1}},
+			"limit":  &graphql.InputObjectFieldConfig{Type: graphql.Float},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "QueryRoot",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{
+							Type: filterType,
+							DefaultValue: map[string]interface{}{
+								"id":     "7",
+								"name":   "Rex \"the dog\"",
+								"colors": []interface{}{0, 1},
+								"limit":  2.5,
+							},
+						},
+						"unset": &graphql.ArgumentConfig{Type: filterType},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        __type(name: "QueryRoot") {
+          fields { args { name defaultValue } }
+        }
+        filter: __type(name: "Filter") {
+          inputFields { name defaultValue }
+        }
+      }
+    `
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"args": []interface{}{
+							map[string]interface{}{
+								"name":         "filter",
+								"defaultValue": `{colors: [RED, BLUE], id: 7, limit: 2.5, name: "Rex \"the dog\""}`,
+							},
+							map[string]interface{}{
+								"name":         "unset",
+								"defaultValue": nil,
+							},
+						},
+					},
+				},
+			},
+			"filter": map[string]interface{}{
+				"inputFields": []interface{}{
+					map[string]interface{}{"name": "colors", "defaultValue": "[BLUE]"},
+					map[string]interface{}{"name": "id", "defaultValue": nil},
+					map[string]interface{}{"name": "limit", "defaultValue": nil},
+					map[string]interface{}{"name": "name", "defaultValue": nil},
+				},
+			},
+		},
+	}
+	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) || len(result.Errors) != 0 {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Values the type can't serialize are treated as null.
+	if v := graphql.AstFromValue("GREEN", colorType); v != nil {
+		t.Fatalf("Expected no literal for an unknown enum value, got %#v", v)
+	}
+	if v := graphql.AstFromValue(nil, graphql.NewNonNull(filterType)); v != nil {
+		t.Fatalf("Expected no literal for null, got %#v", v)
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+const introspectionGoldenSDL = `
+directive @cacheControl(maxAge: Int, scope: CacheScope = PUBLIC, inheritMaxAge: Boolean) on FIELD_DEFINITION | OBJECT
+
+"The root query."
+type Query {
+  node(id: ID!): Node
+  search(text: String!, first: Int = 10, after: String, filter: SearchFilter): [SearchResult!]!
+  pets(kind: PetKind, order: Order = ASC): [Pet]
+}
+
+type Mutation {
+  adoptPet(input: AdoptPetInput!): Pet
+}
+
+interface Node {
+  id: ID!
+}
+
+interface Pet {
+  name: String
+  age(unit: AgeUnit = YEARS, rounded: Boolean): Int
+}
+
+type Dog implements Node & Pet {
+  id: ID!
+  name: String
+  age(unit: AgeUnit = YEARS, rounded: Boolean): Int
+  barks: Boolean @deprecated(reason: "Dogs bark.")
+}
+
+type Cat implements Node & Pet {
+  id: ID!
+  name: String
+  age(unit: AgeUnit = YEARS, rounded: Boolean): Int
+  lives: Int
+}
+
+type Bird implements Pet {
+  name: String
+  age(unit: AgeUnit = YEARS, rounded: Boolean): Int
+  wingspan: Float
+}
+
+type Owner implements Node {
+  id: ID!
+  pets: [Pet!]
+}
+
+union SearchResult = Owner | Dog | Cat | Bird
+
+enum PetKind {
+  DOG
+  CAT
+  BIRD
+  HAMSTER @deprecated
+  FERRET
+}
+
+enum AgeUnit {
+  YEARS
+  MONTHS
+  DAYS
+}
+
+enum Order {
+  ASC
+  DESC
+}
+
+enum CacheScope {
+  PUBLIC
+  PRIVATE
+}
+
+input SearchFilter {
+  kinds: [PetKind!]
+  minAge: Int = 0
+  maxAge: Int
+  owner: ID
+}
+
+input AdoptPetInput {
+  petID: ID!
+  ownerName: String!
+  note: String
+}
+`
+
+// TestIntrospection_FullIntrospectionIsStable compares the result of the full
+// introspection query to a golden file. Run `go test -run FullIntrospection
+// -update` to update the file after intentional changes.
+func TestIntrospection_FullIntrospectionIsStable(t *testing.T) {
+	golden := filepath.Join("testdata", "introspection.golden.json")
+	var expected []byte
+	// The schema is built several times as map iteration order differs each
+	// time, which would make any unsorted part of the output change.
+	for i := 0; i < 5; i++ {
+		schema, err := graphql.BuildSchema(introspectionGoldenSDL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: testutil.IntrospectionQuery,
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+		actual, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, '\n')
+
+		if i == 0 && *updateGolden {
+			if err := ioutil.WriteFile(golden, actual, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if expected == nil {
+			expected, err = ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !bytes.Equal(expected, actual) {
+			t.Fatalf("Introspection result doesn't match %s, got:\n%s", golden, actual)
+		}
+	}
+}
+
+func TestIntrospection_HidesInvisibleTypesAndFields(t *testing.T) {
+	secretType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Secret",
+		Fields: graphql.Fields{
+			"code": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String},
+		},
+	})
+	hidden := map[string]bool{
+		graphql.VisibilityType + " Secret":        true,
+		graphql.VisibilityField + " Query.secret": true,
+		graphql.VisibilityField + " User.email":   true,
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"name": "Jane", "email": "jane@example.com"}, nil
+					},
+				},
+				"secret": &graphql.Field{Type: secretType},
+			},
+		}),
+		Types: []graphql.Type{secretType},
+		IsVisible: func(kind, name string) bool {
+			return !hidden[kind+" "+name]
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+
+	result := g(t, graphql.Params{
+		Schema: schema,
+		RequestString: `
+      {
+        user: __type(name: "User") { fields { name } }
+        query: __type(name: "Query") { fields { name } }
+        secret: __type(name: "Secret") { name }
+        __schema { types { name } }
+      }
+    `,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "name"},
+			},
+		},
+		"query": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "user"},
+			},
+		},
+		"secret": nil,
+	}
+	for k, v := range expected {
+		if !reflect.DeepEqual(v, data[k]) {
+			t.Fatalf("Unexpected %s, Diff: %v", k, testutil.Diff(v, data[k]))
+		}
+	}
+	for _, ttype := range data["__schema"].(map[string]interface{})["types"].([]interface{}) {
+		if name := ttype.(map[string]interface{})["name"]; name == "Secret" {
+			t.Fatalf("Expected Secret to be left out of __schema.types")
+		}
+	}
+
+	result = g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user { name email } ... on Secret { code } }`,
+	})
+	expectedErrors := []gqlerrors.FormattedError{
+		{
+			Message:   `Cannot query field "email" on type "User".`,
+			Type:      "BAD_QUERY",
+			Locations: []location.SourceLocation{{Line: 1, Column: 15}},
+		},
+		{
+			Message:   `Unknown type "Secret".`,
+			Type:      "BAD_QUERY",
+			Locations: []location.SourceLocation{{Line: 1, Column: 30}},
+		},
+	}
+	if result.Data != nil || !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func TestIntrospection_CachedResultsAreCopiedForEachRequest(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "QueryRoot",
+			Fields: graphql.Fields{
+				"onlyField": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `{ __schema { queryType { name } } }`
+	expected := map[string]interface{}{
+		"__schema": map[string]interface{}{
+			"queryType": map[string]interface{}{"name": "QueryRoot"},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		result := g(t, graphql.Params{Schema: schema, RequestString: query})
+		if len(result.Errors) != 0 {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expected, result.Data) {
+			t.Fatalf("Unexpected result on request %d, Diff: %v", i, testutil.Diff(expected, result.Data))
+		}
+		// Modifying a result mustn't affect the results of later requests.
+		result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["queryType"].(map[string]interface{})["name"] = "Modified"
+	}
+}
+
+func TestIntrospection_IncludesLazilyLoadedTypes(t *testing.T) {
+	extraType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Extra",
+		Fields: graphql.Fields{
+			"a": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "QueryRoot",
+			Fields: graphql.Fields{
+				"onlyField": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		TypeLoader: func(name string) (graphql.Type, error) {
+			if name == "Extra" {
+				return extraType, nil
+			}
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	hasExtra := func() bool {
+		result := g(t, graphql.Params{Schema: schema, RequestString: `{ __schema { types { name } } }`})
+		if len(result.Errors) != 0 {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+		for _, ttype := range result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["types"].([]interface{}) {
+			if ttype.(map[string]interface{})["name"] == "Extra" {
+				return true
+			}
+		}
+		return false
+	}
+	if hasExtra() {
+		t.Fatal("Expected Extra to not be listed before it's loaded")
+	}
+	if result := g(t, graphql.Params{Schema: schema, RequestString: `{ __type(name: "Extra") { name } }`}); len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !hasExtra() {
+		t.Fatal("Expected Extra to be listed once it's loaded")
+	}
+}
@@ -98,14 +98,28 @@ func (vd *VariableDefinition) GetLoc() Location {
 
 // TypeExtensionDefinition implements Node, Definition
 type TypeExtensionDefinition struct {
-	Loc        Location
+	Loc Location
+	// Definition is the definition of the extended type if it's an object type.
 	Definition *ObjectDefinition
+	// Extension is the definition of the extended type if it's any other kind
+	// of type: a *ScalarDefinition, *InterfaceDefinition, *UnionDefinition,
+	// *EnumDefinition, or *InputObjectDefinition.
+	Extension TypeDefinition
 }
 
 func (def *TypeExtensionDefinition) GetLoc() Location {
 	return def.Loc
 }
 
+// ExtendedType returns the definition of the extended type whatever its kind,
+// i.e. Definition for an object type and Extension otherwise.
+func (def *TypeExtensionDefinition) ExtendedType() TypeDefinition {
+	if def.Definition != nil {
+		return def.Definition
+	}
+	return def.Extension
+}
+
 func (def *TypeExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
 	return []*VariableDefinition{}
 }
@@ -120,10 +134,12 @@ func (def *TypeExtensionDefinition) GetOperation() string {
 
 // DirectiveDefinition implements Node, Definition
 type DirectiveDefinition struct {
-	Loc       Location
-	Name      *Name
-	Arguments []*InputValueDefinition
-	Locations []*Name
+	Loc         Location
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Locations   []*Name
+	Description *StringValue
+	Doc         *CommentGroup
 }
 
 func (def *DirectiveDefinition) GetLoc() Location {
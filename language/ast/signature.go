@@ -0,0 +1,143 @@
+package ast
+
+import (
+	"sort"
+	"strings"
+)
+
+// Signature returns a normalized representation of the operation that's the
+// same for all operations of the same shape, which makes it useful to group
+// operations for metrics or caching. Aliases and insignificant whitespace are
+// dropped, literal argument values are replaced by placeholders (0, "", [], {}),
+// and arguments, directives, and selections are sorted. Fragment spreads are
+// kept by name as the operation doesn't include the fragment definitions.
+func (op *OperationDefinition) Signature() string {
+	var sb strings.Builder
+	sb.WriteString(op.Operation)
+	if op.Name != nil {
+		sb.WriteByte(' ')
+		sb.WriteString(op.Name.Value)
+	}
+	if len(op.VariableDefinitions) != 0 {
+		vars := make([]string, 0, len(op.VariableDefinitions))
+		for _, def := range op.VariableDefinitions {
+			if def.Variable == nil || def.Variable.Name == nil || def.Type == nil {
+				continue
+			}
+			v := "$" + def.Variable.Name.Value + ":" + typeSignature(def.Type)
+			if def.DefaultValue != nil {
+				v += "=" + valueSignature(def.DefaultValue)
+			}
+			vars = append(vars, v)
+		}
+		sort.Strings(vars)
+		sb.WriteString("(" + strings.Join(vars, ",") + ")")
+	}
+	sb.WriteString(directivesSignature(op.Directives))
+	sb.WriteString(selectionSetSignature(op.SelectionSet))
+	return sb.String()
+}
+
+func selectionSetSignature(set *SelectionSet) string {
+	if set == nil || len(set.Selections) == 0 {
+		return ""
+	}
+	selections := make([]string, 0, len(set.Selections))
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *Field:
+			s := ""
+			if selection.Name != nil {
+				s = selection.Name.Value
+			}
+			s += argumentsSignature(selection.Arguments)
+			s += directivesSignature(selection.Directives)
+			s += selectionSetSignature(selection.SelectionSet)
+			selections = append(selections, s)
+		case *FragmentSpread:
+			s := "..."
+			if selection.Name != nil {
+				s += selection.Name.Value
+			}
+			s += directivesSignature(selection.Directives)
+			selections = append(selections, s)
+		case *InlineFragment:
+			s := "..."
+			if selection.TypeCondition != nil {
+				s += " on " + selection.TypeCondition.String()
+			}
+			s += directivesSignature(selection.Directives)
+			s += selectionSetSignature(selection.SelectionSet)
+			selections = append(selections, s)
+		}
+	}
+	sort.Strings(selections)
+	return "{" + strings.Join(selections, " ") + "}"
+}
+
+func argumentsSignature(args []*Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sigs := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg.Name == nil {
+			continue
+		}
+		sigs = append(sigs, arg.Name.Value+":"+valueSignature(arg.Value))
+	}
+	sort.Strings(sigs)
+	return "(" + strings.Join(sigs, ",") + ")"
+}
+
+func directivesSignature(directives []*Directive) string {
+	if len(directives) == 0 {
+		return ""
+	}
+	sigs := make([]string, 0, len(directives))
+	for _, dir := range directives {
+		if dir.Name == nil {
+			continue
+		}
+		sigs = append(sigs, "@"+dir.Name.Value+argumentsSignature(dir.Arguments))
+	}
+	sort.Strings(sigs)
+	return " " + strings.Join(sigs, " ")
+}
+
+func typeSignature(t Type) string {
+	switch t := t.(type) {
+	case *List:
+		return "[" + typeSignature(t.Type) + "]"
+	case *NonNull:
+		return typeSignature(t.Type) + "!"
+	}
+	return t.String()
+}
+
+func valueSignature(value Value) string {
+	switch value := value.(type) {
+	case *Variable:
+		if value.Name != nil {
+			return "$" + value.Name.Value
+		}
+	case *IntValue, *FloatValue:
+		return "0"
+	case *StringValue:
+		return `""`
+	case *ListValue:
+		return "[]"
+	case *ObjectValue:
+		return "{}"
+	case *BooleanValue:
+		if value.Value {
+			return "true"
+		}
+		return "false"
+	case *NullValue:
+		return "null"
+	case *EnumValue:
+		return value.Value
+	}
+	return ""
+}
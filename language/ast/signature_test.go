@@ -0,0 +1,45 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+)
+
+func operationSignature(t *testing.T, query string) string {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc.Definitions[0].(*ast.OperationDefinition).Signature()
+}
+
+func TestOperationDefinition_Signature(t *testing.T) {
+	sig1 := operationSignature(t, `
+		query Hero($episode: Episode = JEDI, $withFriends: Boolean!) {
+		  hero(episode: $episode, limit: 10) {
+		    name
+		    friends(first: 5) @include(if: $withFriends) { name }
+		    ... on Droid { primaryFunction }
+		  }
+		}`)
+	sig2 := operationSignature(t, `query Hero($withFriends: Boolean!, $episode: Episode = JEDI) {
+		  hero(limit: 3, episode: $episode) {
+		    ... on Droid { primaryFunction }
+		    buddies: friends(first: 1) @include(if: $withFriends) { name }
+		    name
+		  }
+		}`)
+	expected := `query Hero($episode:Episode=JEDI,$withFriends:Boolean!){hero(episode:$episode,limit:0){... on Droid{primaryFunction} friends(first:0) @include(if:$withFriends){name} name}}`
+	if sig1 != expected {
+		t.Fatalf("Expected signature %q, got %q", expected, sig1)
+	}
+	if sig2 != sig1 {
+		t.Fatalf("Expected the same signature, got %q and %q", sig1, sig2)
+	}
+
+	if sig := operationSignature(t, `query Hero { hero { id } }`); sig == sig1 {
+		t.Fatalf("Expected different operations to have different signatures")
+	}
+}
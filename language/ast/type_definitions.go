@@ -31,6 +31,8 @@ type SchemaDefinition struct {
 	Loc            Location
 	Directives     []*Directive
 	OperationTypes []*OperationTypeDefinition
+	Description    *StringValue
+	Doc            *CommentGroup
 }
 
 func (def *SchemaDefinition) GetLoc() Location {
@@ -62,9 +64,10 @@ func (def *OperationTypeDefinition) GetLoc() Location {
 
 // ScalarDefinition implements Node, Definition
 type ScalarDefinition struct {
-	Loc        Location
-	Name       *Name
-	Directives []*Directive
+	Loc         Location
+	Name        *Name
+	Directives  []*Directive
+	Description *StringValue
 }
 
 func (def *ScalarDefinition) GetLoc() Location {
@@ -89,12 +92,13 @@ func (def *ScalarDefinition) GetOperation() string {
 
 // ObjectDefinition implements Node, Definition
 type ObjectDefinition struct {
-	Loc        Location
-	Name       *Name
-	Interfaces []*Named
-	Directives []*Directive
-	Fields     []*FieldDefinition
-	Doc        *CommentGroup
+	Loc         Location
+	Name        *Name
+	Interfaces  []*Named
+	Directives  []*Directive
+	Fields      []*FieldDefinition
+	Description *StringValue
+	Doc         *CommentGroup
 }
 
 func (def *ObjectDefinition) GetLoc() Location {
@@ -119,13 +123,14 @@ func (def *ObjectDefinition) GetOperation() string {
 
 // FieldDefinition implements Node
 type FieldDefinition struct {
-	Loc        Location
-	Name       *Name
-	Arguments  []*InputValueDefinition
-	Type       Type
-	Doc        *CommentGroup
-	Comment    *CommentGroup
-	Directives []*Directive
+	Loc         Location
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Type        Type
+	Description *StringValue
+	Doc         *CommentGroup
+	Comment     *CommentGroup
+	Directives  []*Directive
 }
 
 func (def *FieldDefinition) GetLoc() Location {
@@ -138,6 +143,7 @@ type InputValueDefinition struct {
 	Name         *Name
 	Type         Type
 	DefaultValue Value
+	Description  *StringValue
 	Doc          *CommentGroup
 	Comment      *CommentGroup
 	Directives   []*Directive
@@ -156,11 +162,13 @@ func (def *InputValueDefinition) String() string {
 
 // InterfaceDefinition implements Node, Definition
 type InterfaceDefinition struct {
-	Loc        Location
-	Name       *Name
-	Fields     []*FieldDefinition
-	Directives []*Directive
-	Doc        *CommentGroup
+	Loc         Location
+	Name        *Name
+	Interfaces  []*Named
+	Fields      []*FieldDefinition
+	Directives  []*Directive
+	Description *StringValue
+	Doc         *CommentGroup
 }
 
 func (def *InterfaceDefinition) GetLoc() Location {
@@ -185,12 +193,13 @@ func (def *InterfaceDefinition) GetOperation() string {
 
 // UnionDefinition implements Node, Definition
 type UnionDefinition struct {
-	Loc        Location
-	Name       *Name
-	Directives []*Directive
-	Types      []*Named
-	Doc        *CommentGroup
-	Comment    *CommentGroup
+	Loc         Location
+	Name        *Name
+	Directives  []*Directive
+	Types       []*Named
+	Description *StringValue
+	Doc         *CommentGroup
+	Comment     *CommentGroup
 }
 
 func (def *UnionDefinition) GetLoc() Location {
@@ -215,11 +224,12 @@ func (def *UnionDefinition) GetOperation() string {
 
 // EnumDefinition implements Node, Definition
 type EnumDefinition struct {
-	Loc        Location
-	Name       *Name
-	Directives []*Directive
-	Values     []*EnumValueDefinition
-	Doc        *CommentGroup
+	Loc         Location
+	Name        *Name
+	Directives  []*Directive
+	Values      []*EnumValueDefinition
+	Description *StringValue
+	Doc         *CommentGroup
 }
 
 func (def *EnumDefinition) GetLoc() Location {
@@ -244,11 +254,12 @@ func (def *EnumDefinition) GetOperation() string {
 
 // EnumValueDefinition implements Node, Definition
 type EnumValueDefinition struct {
-	Loc        Location
-	Name       *Name
-	Directives []*Directive
-	Doc        *CommentGroup
-	Comment    *CommentGroup
+	Loc         Location
+	Name        *Name
+	Directives  []*Directive
+	Description *StringValue
+	Doc         *CommentGroup
+	Comment     *CommentGroup
 }
 
 func (def *EnumValueDefinition) GetLoc() Location {
@@ -257,11 +268,12 @@ func (def *EnumValueDefinition) GetLoc() Location {
 
 // InputObjectDefinition implements Node, Definition
 type InputObjectDefinition struct {
-	Loc        Location
-	Name       *Name
-	Directives []*Directive
-	Fields     []*InputValueDefinition
-	Doc        *CommentGroup
+	Loc         Location
+	Name        *Name
+	Directives  []*Directive
+	Fields      []*InputValueDefinition
+	Description *StringValue
+	Doc         *CommentGroup
 }
 
 func (def *InputObjectDefinition) GetLoc() Location {
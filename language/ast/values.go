@@ -11,6 +11,7 @@ var _ Value = (*IntValue)(nil)
 var _ Value = (*FloatValue)(nil)
 var _ Value = (*StringValue)(nil)
 var _ Value = (*BooleanValue)(nil)
+var _ Value = (*NullValue)(nil)
 var _ Value = (*EnumValue)(nil)
 var _ Value = (*ListValue)(nil)
 var _ Value = (*ObjectValue)(nil)
@@ -66,6 +67,8 @@ func (v *FloatValue) GetValue() interface{} {
 type StringValue struct {
 	Loc   Location
 	Value string
+	// Block is true if the value was written as a block string ("""...""").
+	Block bool
 }
 
 func (v *StringValue) GetLoc() Location {
@@ -90,6 +93,19 @@ func (v *BooleanValue) GetValue() interface{} {
 	return v.Value
 }
 
+// NullValue implements Node, Value
+type NullValue struct {
+	Loc Location
+}
+
+func (v *NullValue) GetLoc() Location {
+	return v.Loc
+}
+
+func (v *NullValue) GetValue() interface{} {
+	return nil
+}
+
 // EnumValue implements Node, Value
 type EnumValue struct {
 	Loc   Location
@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/source"
 )
 
+// TokenKind identifies the kind of a lexed Token.
+type TokenKind int
+
 const (
-	EOF = iota + 1
+	EOF TokenKind = iota + 1
 	BANG
 	DOLLAR
 	PAREN_L
@@ -31,12 +35,44 @@ const (
 	STRING
 	COMMENT
 	AMPERSAND
+	BLOCK_STRING
 )
 
-var tokenDescription map[int]string
+var tokenKindNames = map[TokenKind]string{
+	EOF:          "EOF",
+	BANG:         "BANG",
+	DOLLAR:       "DOLLAR",
+	PAREN_L:      "PAREN_L",
+	PAREN_R:      "PAREN_R",
+	SPREAD:       "SPREAD",
+	COLON:        "COLON",
+	EQUALS:       "EQUALS",
+	AT:           "AT",
+	BRACKET_L:    "BRACKET_L",
+	BRACKET_R:    "BRACKET_R",
+	BRACE_L:      "BRACE_L",
+	PIPE:         "PIPE",
+	BRACE_R:      "BRACE_R",
+	NAME:         "NAME",
+	INT:          "INT",
+	FLOAT:        "FLOAT",
+	STRING:       "STRING",
+	COMMENT:      "COMMENT",
+	AMPERSAND:    "AMPERSAND",
+	BLOCK_STRING: "BLOCK_STRING",
+}
+
+var tokenKindsByName map[string]TokenKind
+
+var tokenDescription map[TokenKind]string
 
 func init() {
-	tokenDescription = make(map[int]string)
+	tokenKindsByName = make(map[string]TokenKind, len(tokenKindNames))
+	for kind, name := range tokenKindNames {
+		tokenKindsByName[name] = kind
+	}
+
+	tokenDescription = make(map[TokenKind]string)
 	tokenDescription[EOF] = "EOF"
 	tokenDescription[BANG] = "!"
 	tokenDescription[DOLLAR] = "$"
@@ -57,12 +93,29 @@ func init() {
 	tokenDescription[STRING] = "String"
 	tokenDescription[COMMENT] = "Comment"
 	tokenDescription[AMPERSAND] = "&"
+	tokenDescription[BLOCK_STRING] = "BlockString"
+}
+
+// String returns the name of the token kind as it's spelled in this
+// package, e.g. "NAME" or "BRACE_L".
+func (k TokenKind) String() string {
+	if name, ok := tokenKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// TokenKindByName returns the token kind with the given name, the reverse
+// of TokenKind.String. The second return value is false if no kind has the name.
+func TokenKindByName(name string) (TokenKind, bool) {
+	kind, ok := tokenKindsByName[name]
+	return kind, ok
 }
 
 // Token is a representation of a lexed Token. Value only appears for non-punctuation
-// tokens: NAME, INT, FLOAT, and STRING.
+// tokens: NAME, INT, FLOAT, STRING, and BLOCK_STRING.
 type Token struct {
-	Kind  int
+	Kind  TokenKind
 	Start int
 	End   int
 	Value string
@@ -81,6 +134,10 @@ type Lexer struct {
 	ch       rune
 }
 
+// eof is the current character once the whole body has been read, which
+// sets it apart from NUL characters within the body.
+const eof = -1
+
 type offset struct {
 	bytes int
 	runes int
@@ -106,7 +163,7 @@ func (l *Lexer) NextToken() (Token, error) {
 func (l *Lexer) nextRune() {
 	l.offset = l.rdOffset
 	if l.rdOffset.bytes >= len(l.body) {
-		l.ch = 0
+		l.ch = eof
 		return
 	}
 	l.offset = l.rdOffset
@@ -131,10 +188,10 @@ func (l *Lexer) nextRune() {
 func (l *Lexer) readName() (Token, error) {
 	start := l.offset
 	for {
-		if !(l.ch != 0 && (l.ch == 95 || // _
+		if !(l.ch == 95 || // _
 			l.ch >= 48 && l.ch <= 57 || // 0-9
 			l.ch >= 65 && l.ch <= 90 || // A-Z
-			l.ch >= 97 && l.ch <= 122)) { // a-z
+			l.ch >= 97 && l.ch <= 122) { // a-z
 			break
 		}
 		l.nextRune()
@@ -194,7 +251,7 @@ func (l *Lexer) readNumber() (Token, error) {
 func (l *Lexer) readDigits() error {
 	if l.ch < '0' || l.ch > '9' {
 		var description string
-		if l.ch != 0 {
+		if l.ch != eof {
 			description = fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(l.ch))
 		} else {
 			description = "Invalid number, expected digit but got: EOF."
@@ -210,39 +267,57 @@ func (l *Lexer) readDigits() error {
 func (l *Lexer) readString() (Token, error) {
 	start := l.offset
 	chunkStart := l.rdOffset
-	value := make([]string, 0, 16)
+	// Strings without escape sequences are sliced from the body. Otherwise
+	// the unescaped value is built up in value.
+	var value strings.Builder
+	escaped := false
 	for {
 		l.nextRune()
 
-		if l.ch == 0 || l.ch == '"' || l.ch == 10 || l.ch == 13 {
+		if l.ch == eof || l.ch == '"' || l.ch == 10 || l.ch == 13 {
 			break
 		}
 		if l.ch < 0x0020 && l.ch != 0x0009 {
 			return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(l.ch)))
 		}
 		if l.ch == '\\' {
-			value = append(value, l.sliceBody(chunkStart, l.offset))
+			if !escaped {
+				// The raw length of the string is an upper bound on the
+				// unescaped length, letting the value be built with a single
+				// allocation.
+				value.Grow(stringEnd(l.body, l.offset.bytes) - chunkStart.bytes)
+				escaped = true
+			}
+			value.WriteString(l.body[chunkStart.bytes:l.offset.bytes])
 			l.nextRune()
 			switch l.ch {
 			case '"':
-				value = append(value, "\"")
+				value.WriteByte('"')
 			case '/':
-				value = append(value, "/")
+				value.WriteByte('/')
 			case '\\':
-				value = append(value, "\\")
+				value.WriteByte('\\')
 			case 'b':
-				value = append(value, "\b")
+				value.WriteByte('\b')
 			case 'f':
-				value = append(value, "\f")
+				value.WriteByte('\f')
 			case 'n':
-				value = append(value, "\n")
+				value.WriteByte('\n')
 			case 'r':
-				value = append(value, "\r")
+				value.WriteByte('\r')
 			case 't':
-				value = append(value, "\t")
+				value.WriteByte('\t')
 			case 'u':
 				offs := l.rdOffset
 				l.nextRune()
+				if l.ch == '{' {
+					charCode := l.readBracedUnicode()
+					if charCode < 0 {
+						return Token{}, gqlerrors.NewSyntaxError(l.src, offs.runes-1, fmt.Sprintf(`Invalid character escape sequence: \u%s`, l.sliceBody(offs, l.rdOffset)))
+					}
+					value.WriteRune(charCode)
+					break
+				}
 				u1 := l.ch
 				l.nextRune()
 				u2 := l.ch
@@ -254,7 +329,23 @@ func (l *Lexer) readString() (Token, error) {
 				if charCode < 0 {
 					return Token{}, gqlerrors.NewSyntaxError(l.src, offs.runes-1, fmt.Sprintf(`Invalid character escape sequence: \u%s`, l.sliceBody(offs, l.rdOffset)))
 				}
-				value = append(value, string(charCode))
+				if utf16.IsSurrogate(charCode) {
+					// Only a leading surrogate that's immediately followed by
+					// an escaped trailing one is valid, and the pair encodes a
+					// single code point.
+					end := l.rdOffset
+					if trail := l.trailingSurrogate(); charCode < 0xDC00 && trail >= 0 {
+						for i := 0; i < 6; i++ {
+							l.nextRune()
+						}
+						charCode = utf16.DecodeRune(charCode, trail)
+					} else {
+						return Token{}, gqlerrors.NewSyntaxError(l.src, offs.runes-1, fmt.Sprintf(`Invalid character escape sequence: \u%s`, l.sliceBody(offs, end)))
+					}
+				}
+				value.WriteRune(charCode)
+			case eof:
+				return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, "Unterminated string.")
 			default:
 				return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, fmt.Sprintf(`Invalid character escape sequence: \%c.`, l.ch))
 			}
@@ -264,11 +355,144 @@ func (l *Lexer) readString() (Token, error) {
 	if l.ch != '"' {
 		return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, "Unterminated string.")
 	}
-	if chunkStart.bytes != l.offset.bytes {
-		value = append(value, l.sliceBody(chunkStart, l.offset))
+	if !escaped {
+		end := l.offset
+		l.nextRune()
+		return makeToken(STRING, start, l.offset, l.sliceBody(chunkStart, end)), nil
+	}
+	value.WriteString(l.body[chunkStart.bytes:l.offset.bytes])
+	l.nextRune()
+	return makeToken(STRING, start, l.offset, value.String()), nil
+}
+
+// stringEnd returns the byte offset of the closing quote of the string that
+// contains offset i of body, or of the end of the line if it's unterminated.
+// Escaped characters are skipped so \" doesn't end the string.
+func stringEnd(body string, i int) int {
+	for ; i < len(body); i++ {
+		switch body[i] {
+		case '"', '\n', '\r':
+			return i
+		case '\\':
+			i++
+		}
 	}
+	return len(body)
+}
+
+// readBlockString reads a block string ("""...""") from the source. Its
+// value is the raw text between the quotes, with only \""" unescaped, after
+// removing the indentation common to all of its lines and any leading and
+// trailing blank lines.
+func (l *Lexer) readBlockString() (Token, error) {
+	start := l.offset
+	l.nextRune()
 	l.nextRune()
-	return makeToken(STRING, start, l.offset, strings.Join(value, "")), nil
+	l.nextRune()
+	chunkStart := l.offset
+	var raw strings.Builder
+	for {
+		switch {
+		case l.ch == eof:
+			return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, "Unterminated string.")
+		case l.ch == '"' && strings.HasPrefix(l.body[l.offset.bytes:], `"""`):
+			raw.WriteString(l.body[chunkStart.bytes:l.offset.bytes])
+			l.nextRune()
+			l.nextRune()
+			l.nextRune()
+			return makeToken(BLOCK_STRING, start, l.offset, blockStringValue(raw.String())), nil
+		case l.ch == '\\' && strings.HasPrefix(l.body[l.offset.bytes:], `\"""`):
+			raw.WriteString(l.body[chunkStart.bytes:l.offset.bytes])
+			raw.WriteString(`"""`)
+			for i := 0; i < 4; i++ {
+				l.nextRune()
+			}
+			chunkStart = l.offset
+			continue
+		case l.ch < 0x0020 && l.ch != 0x0009 && l.ch != 0x000A && l.ch != 0x000D:
+			return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(l.ch)))
+		}
+		l.nextRune()
+	}
+}
+
+// blockStringValue returns the value of a block string from its raw text as
+// described by the BlockStringValue algorithm of the spec.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(raw), "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhitespace(line)
+		if indent < len(line) && (commonIndent < 0 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+	if commonIndent > 0 {
+		for i, line := range lines[1:] {
+			if len(line) < commonIndent {
+				lines[i+1] = ""
+			} else {
+				lines[i+1] = line[commonIndent:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && leadingWhitespace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && leadingWhitespace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// leadingWhitespace returns the number of spaces and tabs at the start of s.
+func leadingWhitespace(s string) int {
+	i := 0
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// Reads the hexidecimal digits and closing brace of a variable-length
+// unicode escape such as \u{1F600}, with the opening brace being the current
+// char. Returns a negative number if the escape is malformed, has more than
+// six digits, or isn't a valid unicode scalar value.
+func (l *Lexer) readBracedUnicode() rune {
+	var code rune
+	digits := 0
+	for {
+		l.nextRune()
+		if l.ch == '}' {
+			break
+		}
+		h := char2hex(l.ch)
+		if h < 0 || digits == 6 {
+			return -1
+		}
+		code = code<<4 | rune(h)
+		digits++
+	}
+	if digits == 0 || code > unicode.MaxRune || (code >= 0xD800 && code <= 0xDFFF) {
+		return -1
+	}
+	return code
+}
+
+// trailingSurrogate returns the code of the \uXXXX escape that follows the
+// current char if it's a trailing surrogate, or a negative number otherwise.
+func (l *Lexer) trailingSurrogate() rune {
+	rest := l.body[l.rdOffset.bytes:]
+	if len(rest) < 6 || rest[0] != '\\' || rest[1] != 'u' {
+		return -1
+	}
+	code := uniCharCode(rune(rest[2]), rune(rest[3]), rune(rest[4]), rune(rest[5]))
+	if code < 0xDC00 || code > 0xDFFF {
+		return -1
+	}
+	return code
 }
 
 // Converts four hexidecimal chars to the integer that the
@@ -298,13 +522,12 @@ func char2hex(a rune) int {
 	return -1
 }
 
-func makeToken(kind int, start, end offset, value string) Token {
+func makeToken(kind TokenKind, start, end offset, value string) Token {
 	return Token{Kind: kind, Start: start.runes, End: end.runes, Value: value}
 }
 
 func printCharCode(code rune) string {
-	// NaN/undefined represents access beyond the end of the file.
-	if code < 0 {
+	if code == eof {
 		return "<EOF>"
 	}
 	// print as ASCII for printable range
@@ -317,9 +540,12 @@ func printCharCode(code rune) string {
 
 func (l *Lexer) readToken() (Token, error) {
 	l.skipWhitespace()
-	if l.ch == 0 {
+	if l.ch == eof {
 		return makeToken(EOF, l.rdOffset, l.rdOffset, ""), nil
 	}
+	if l.ch == 0 {
+		return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, fmt.Sprintf(`Cannot contain the invalid character %v.`, printCharCode(l.ch)))
+	}
 	// SourceCharacter
 	if l.ch < 0x0020 && l.ch != 0x0009 && l.ch != 0x000A && l.ch != 0x000D {
 		return Token{}, gqlerrors.NewSyntaxError(l.src, l.offset.runes, fmt.Sprintf(`Invalid character %v`, printCharCode(l.ch)))
@@ -332,6 +558,9 @@ func (l *Lexer) readToken() (Token, error) {
 	case isDigit(ch) || ch == '-':
 		return l.readNumber()
 	case ch == '"':
+		if strings.HasPrefix(l.body[l.offset.bytes:], `"""`) {
+			return l.readBlockString()
+		}
 		return l.readString()
 	default:
 		l.nextRune() // always make progress
@@ -371,7 +600,7 @@ func (l *Lexer) readToken() (Token, error) {
 			return makeToken(BRACE_R, startOffset, l.offset, ""), nil
 		case '#':
 			for {
-				if l.ch == '\n' || l.ch == '\r' || l.ch == 0 {
+				if l.ch == '\n' || l.ch == '\r' || l.ch == eof {
 					break
 				}
 				l.nextRune()
@@ -418,6 +647,6 @@ func GetTokenDesc(token Token) string {
 	return fmt.Sprintf("%s %q", GetTokenKindDesc(token.Kind), token.Value)
 }
 
-func GetTokenKindDesc(kind int) string {
+func GetTokenKindDesc(kind TokenKind) string {
 	return tokenDescription[kind]
 }
@@ -1,8 +1,10 @@
 package lexer
 
 import (
+	"io/ioutil"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/sprucehealth/graphql/language/source"
@@ -64,6 +66,27 @@ func TestLexer_GetTokenDesc(t *testing.T) {
 
 }
 
+func TestLexer_TokenKindString(t *testing.T) {
+	for kind := EOF; kind <= BLOCK_STRING; kind++ {
+		name := kind.String()
+		if k, ok := TokenKindByName(name); !ok || k != kind {
+			t.Errorf("TokenKindByName(%q) = %v, %v; expected %v, true", name, k, ok, kind)
+		}
+	}
+	if s := NAME.String(); s != "NAME" {
+		t.Errorf("Expected NAME, got %s", s)
+	}
+	if s := BRACE_L.String(); s != "BRACE_L" {
+		t.Errorf("Expected BRACE_L, got %s", s)
+	}
+	if s := TokenKind(0).String(); s != "TokenKind(0)" {
+		t.Errorf("Expected TokenKind(0), got %s", s)
+	}
+	if _, ok := TokenKindByName("Name"); ok {
+		t.Error("Expected no token kind named Name")
+	}
+}
+
 func TestLexer_DisallowsUncommonControlCharacters(t *testing.T) {
 	tests := []Test{
 		{
@@ -109,6 +132,70 @@ func TestLexer_AcceptsBOMHeader(t *testing.T) {
 	}
 }
 
+func TestLexer_ReportsLocationsAfterBOMHeader(t *testing.T) {
+	expected := "Syntax Error GraphQL (2:3) Unexpected character \"?\".\n\n" +
+		"1: \uFEFFfoo\n" +
+		"2:   ?\n" +
+		"     ^\n"
+	lex := New(source.New("GraphQL", "\uFEFFfoo\n  ?"))
+	if token, err := lex.NextToken(); err != nil || token.Value != "foo" {
+		t.Fatalf("unexpected token %+v, error: %v", token, err)
+	}
+	_, err := lex.NextToken()
+	if err == nil {
+		t.Fatal("unexpected nil error")
+	}
+	if err.Error() != expected {
+		t.Errorf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestLexer_DisallowsNULCharacters(t *testing.T) {
+	tests := []Test{
+		{
+			Body: "foo \u0000 bar",
+			Expected: `Syntax Error GraphQL (1:5) Cannot contain the invalid character "\\u0000".
+
+1: foo \u0000 bar
+       ^
+`,
+		},
+		{
+			Body: "\"foo\u0000\"",
+			Expected: `Syntax Error GraphQL (1:5) Invalid character within String: "\\u0000".
+
+1: "foo\u0000"
+       ^
+`,
+		},
+		{
+			Body: "\"\"\"foo\u0000\"\"\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character within String: "\\u0000".
+
+1: """foo\u0000"""
+         ^
+`,
+		},
+	}
+	for _, test := range tests {
+		lex := New(source.New("GraphQL", test.Body))
+		var err error
+		for err == nil {
+			var token Token
+			if token, err = lex.NextToken(); token.Kind == EOF {
+				break
+			}
+		}
+		if err == nil {
+			t.Errorf("unexpected nil error\nexpected:\n%v", test.Expected)
+			continue
+		}
+		if err.Error() != test.Expected {
+			t.Errorf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", test.Expected, err.Error())
+		}
+	}
+}
+
 func TestLexer_SkipsWhiteSpace(t *testing.T) {
 	tests := []Test{
 		{
@@ -289,6 +376,33 @@ func TestLexer_LexesStrings(t *testing.T) {
 				Value: "unicode \u1234\u5678\u90AB\uCDEF",
 			},
 		},
+		{
+			Body: "\"\\u{1F600}\"",
+			Expected: Token{
+				Kind:  STRING,
+				Start: 0,
+				End:   11,
+				Value: "\U0001F600",
+			},
+		},
+		{
+			Body: "\"smile \\uD83D\\uDE00!\"",
+			Expected: Token{
+				Kind:  STRING,
+				Start: 0,
+				End:   21,
+				Value: "smile \U0001F600!",
+			},
+		},
+		{
+			Body: "\"a\\u{0}b\\u{00e9}\"",
+			Expected: Token{
+				Kind:  STRING,
+				Start: 0,
+				End:   17,
+				Value: "a\x00b\u00e9",
+			},
+		},
 		{
 			Body: "\"unicode фы世界\"",
 			Expected: Token{
@@ -420,6 +534,70 @@ func TestLexer_ReportsUsefulStringErrors(t *testing.T) {
 
 1: "bad \u123
          ^
+`,
+		},
+		{
+			Body: "\"bad \\u{110000} esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{110000}
+
+1: "bad \u{110000} esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\u{1234567} esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{1234567
+
+1: "bad \u{1234567} esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\u{} esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{}
+
+1: "bad \u{} esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\u{D800} esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \u{D800}
+
+1: "bad \u{D800} esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\uD800 esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uD800
+
+1: "bad \uD800 esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\uDE00 esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uDE00
+
+1: "bad \uDE00 esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\uD83D\\u0041 esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uD83D
+
+1: "bad \uD83D\u0041 esc"
+         ^
+`,
+		},
+		{
+			Body: "\"bad \\uD83D\\uD83D esc\"",
+			Expected: `Syntax Error GraphQL (1:7) Invalid character escape sequence: \uD83D
+
+1: "bad \uD83D\uD83D esc"
+         ^
 `,
 		},
 		{
@@ -446,6 +624,77 @@ func TestLexer_ReportsUsefulStringErrors(t *testing.T) {
 	}
 }
 
+func TestLexer_LexesBlockStrings(t *testing.T) {
+	tests := []Test{
+		{
+			Body: `"""simple"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   12,
+				Value: "simple",
+			},
+		},
+		{
+			Body: `"""contains " quote"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   22,
+				Value: `contains " quote`,
+			},
+		},
+		{
+			Body: `"""contains \""" triplequote"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   31,
+				Value: `contains """ triplequote`,
+			},
+		},
+		{
+			Body: `"""unescaped \n\r\b\t\f\u1234"""`,
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   32,
+				Value: `unescaped \n\r\b\t\f\u1234`,
+			},
+		},
+		{
+			Body: "\"\"\"\n\n    spans\n      multiple\r\n    lines\n\n  \"\"\"",
+			Expected: Token{
+				Kind:  BLOCK_STRING,
+				Start: 0,
+				End:   47,
+				Value: "spans\n  multiple\nlines",
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			token, err := New(source.New("", test.Body)).NextToken()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(token, test.Expected) {
+				t.Fatalf("unexpected token, expected: %v, got: %v", test.Expected, token)
+			}
+		})
+	}
+
+	_, err := New(createSource(`"""no end quote`)).NextToken()
+	expected := `Syntax Error GraphQL (1:16) Unterminated string.
+
+1: """no end quote
+                  ^
+`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", expected, err)
+	}
+}
+
 func TestLexer_LexesNumbers(t *testing.T) {
 	tests := []Test{
 		{
@@ -1019,6 +1268,27 @@ func TestFullDocument(t *testing.T) {
 	}
 }
 
+func TestLexer_DoesNotAllocateForTokenValues(t *testing.T) {
+	// Token values are sub-slices of the source body, so only strings with
+	// escape sequences or block strings need to allocate.
+	src := createSource(`query Q($var: Int = 1) { field(a: 123, b: -1.5e3, c: $var, d: "plain") @skip(if: true) { id ...F } }`)
+	allocs := testing.AllocsPerRun(100, func() {
+		lex := New(src)
+		for {
+			tok, err := lex.NextToken()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tok.Kind == EOF {
+				break
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("Expected lexing to not allocate, got %v allocations", allocs)
+	}
+}
+
 func BenchmarkLexer(b *testing.B) {
 	body := `
 		# Comment
@@ -1063,6 +1333,52 @@ func BenchmarkLexer(b *testing.B) {
 	}
 }
 
+func BenchmarkLexer_KitchenSink(b *testing.B) {
+	for _, file := range []string{"kitchen-sink.graphql", "schema-kitchen-sink.graphql"} {
+		buf, err := ioutil.ReadFile("../../" + file)
+		if err != nil {
+			b.Fatalf("unable to load %s: %s", file, err)
+		}
+		// Repeat the document to get a large body
+		source := createSource(strings.Repeat(string(buf), 50))
+		b.Run(file, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(source.Body())))
+			for i := 0; i < b.N; i++ {
+				lex := New(source)
+				for {
+					tok, err := lex.NextToken()
+					if err != nil {
+						b.Fatal(err)
+					}
+					if tok.Kind == EOF {
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkLexer_SingleLine(b *testing.B) {
+	// Many escaped strings on one long line, as in compactly printed queries.
+	source := createSource("{" + strings.Repeat(` f(a: "x\ty\u0041") `, 8<<10) + "}")
+	b.ReportAllocs()
+	b.SetBytes(int64(len(source.Body())))
+	for i := 0; i < b.N; i++ {
+		lex := New(source)
+		for {
+			tok, err := lex.NextToken()
+			if err != nil {
+				b.Fatal(err)
+			}
+			if tok.Kind == EOF {
+				break
+			}
+		}
+	}
+}
+
 func BenchmarkLexerAndSourceCreation(b *testing.B) {
 	body := `
 		# Comment
@@ -1,33 +1,57 @@
 package location
 
-import (
-	"regexp"
-
-	"github.com/sprucehealth/graphql/language/source"
-)
+import "github.com/sprucehealth/graphql/language/source"
 
 type SourceLocation struct {
 	Line   int `json:"line"`
 	Column int `json:"column"`
+	// Source names the file or source the location is in. It's only set for
+	// locations in sources created with source.Concat.
+	Source string `json:"source,omitempty"`
 }
 
+// GetLocation returns the location of position in the source adjusted by the
+// source's location offset, so it's relative to the file containing the source.
 func GetLocation(s *source.Source, position int) SourceLocation {
+	l := GetBodyLocation(s, position)
+	if s == nil {
+		return l
+	}
+	offset := s.LocationOffset()
+	if l.Line == 1 {
+		l.Column += offset.Column - 1
+	}
+	l.Line += offset.Line - 1
+	return l
+}
+
+// GetBodyLocation returns the location of position relative to the start of the
+// body of the source, ignoring any location offset.
+func GetBodyLocation(s *source.Source, position int) SourceLocation {
 	body := ""
 	if s != nil {
 		body = s.Body()
 	}
+	// Positions are counted in runes, as the lexer does, so that characters
+	// encoded with more than one byte (such as a leading byte order mark)
+	// don't shift the columns of the lines that follow them.
 	line := 1
-	column := position + 1
-	lineRegexp := regexp.MustCompile("\r\n|[\n\r]")
-	matches := lineRegexp.FindAllStringIndex(body, -1)
-	for _, match := range matches {
-		matchIndex := match[0]
-		if matchIndex >= position {
+	lineStart := 0
+	pos := 0
+	var prev rune
+	for _, r := range body {
+		if pos >= position {
 			break
 		}
-		line++
-		l := len(body[match[0]:match[1]])
-		column = position + 1 - (matchIndex + l)
+		pos++
+		switch {
+		case r == '\n' && prev == '\r':
+			lineStart = pos
+		case r == '\n' || r == '\r':
+			line++
+			lineStart = pos
+		}
+		prev = r
 	}
-	return SourceLocation{Line: line, Column: column}
+	return SourceLocation{Line: line, Column: position + 1 - lineStart}
 }
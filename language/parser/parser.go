@@ -53,6 +53,39 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	return doc, nil
 }
 
+// ParseType parses a type reference on its own, such as `[Int!]!`.
+func ParseType(body string) (ast.Type, error) {
+	parser, err := makeParser(source.New("GraphQL", body), ParseOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ttype, err := parser.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parser.expect(lexer.EOF); err != nil {
+		return nil, err
+	}
+	return ttype, nil
+}
+
+// ParseValue parses a value literal on its own, such as `{a: 1, b: [true, null]}`.
+// The value may contain variables.
+func ParseValue(body string) (ast.Value, error) {
+	parser, err := makeParser(source.New("GraphQL", body), ParseOptions{})
+	if err != nil {
+		return nil, err
+	}
+	value, err := parser.parseValueLiteral(false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parser.expect(lexer.EOF); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 // Converts a name lex token into a name parse node.
 func (p *Parser) parseName() (*ast.Name, error) {
 	token, err := p.expect(lexer.NAME)
@@ -92,8 +125,17 @@ func (p *Parser) parseDocument() (*ast.Document, error) {
 				return nil, err
 			}
 			nodes = append(nodes, node)
-		case p.peek(lexer.NAME):
-			switch p.tok.Value {
+		case p.peek(lexer.NAME), p.peek(lexer.STRING), p.peek(lexer.BLOCK_STRING):
+			keyword := p.tok.Value
+			if !p.peek(lexer.NAME) {
+				// A description may only precede a type system definition so
+				// look past it for the keyword.
+				var err error
+				if keyword, err = p.lookaheadKeyword(); err != nil {
+					return nil, err
+				}
+			}
+			switch keyword {
 			case "query", "mutation", "subscription": // Note: subscription is an experimental non-spec addition.
 				node, err := p.parseOperationDefinition()
 				if err != nil {
@@ -545,13 +587,14 @@ func (p *Parser) parseValueLiteral(isConst bool) (ast.Value, error) {
 			Value: token.Value,
 			Loc:   p.loc(token.Start),
 		}, nil
-	case lexer.STRING:
+	case lexer.STRING, lexer.BLOCK_STRING:
 		if err := p.advance(); err != nil {
 			return nil, err
 		}
 		return &ast.StringValue{
 			Value: token.Value,
 			Loc:   p.loc(token.Start),
+			Block: token.Kind == lexer.BLOCK_STRING,
 		}, nil
 	case lexer.NAME:
 		if token.Value == "true" || token.Value == "false" {
@@ -562,15 +605,19 @@ func (p *Parser) parseValueLiteral(isConst bool) (ast.Value, error) {
 				Value: token.Value == "true",
 				Loc:   p.loc(token.Start),
 			}, nil
-		} else if token.Value != "null" {
-			if err := p.advance(); err != nil {
-				return nil, err
-			}
-			return &ast.EnumValue{
-				Value: token.Value,
-				Loc:   p.loc(token.Start),
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if token.Value == "null" {
+			return &ast.NullValue{
+				Loc: p.loc(token.Start),
 			}, nil
 		}
+		return &ast.EnumValue{
+			Value: token.Value,
+			Loc:   p.loc(token.Start),
+		}, nil
 	case lexer.DOLLAR:
 		if !isConst {
 			return p.parseVariable()
@@ -745,10 +792,43 @@ func (p *Parser) parseNamed() (*ast.Named, error) {
 	}, nil
 }
 
+// parseDescription parses the optional string that describes a type system
+// definition.
+func (p *Parser) parseDescription() (*ast.StringValue, error) {
+	if !p.peek(lexer.STRING) && !p.peek(lexer.BLOCK_STRING) {
+		return nil, nil
+	}
+	value, err := p.parseValueLiteral(true)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*ast.StringValue), nil
+}
+
+// lookaheadKeyword returns the value of the first name token after the current
+// token without advancing the parser.
+func (p *Parser) lookaheadKeyword() (string, error) {
+	lex := *p.Lexer
+	for {
+		tok, err := lex.NextToken()
+		if err != nil {
+			return "", err
+		}
+		if tok.Kind != lexer.COMMENT {
+			return tok.Value, nil
+		}
+	}
+}
+
 // SchemaDefinition : schema { OperationTypeDefinition+ }
 func (p *Parser) parseSchemaDefinition() (*ast.SchemaDefinition, error) {
+	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("schema")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("schema")
 	if err != nil {
 		return nil, err
 	}
@@ -767,9 +847,11 @@ func (p *Parser) parseSchemaDefinition() (*ast.SchemaDefinition, error) {
 		}
 	}
 	return &ast.SchemaDefinition{
+		Description:    description,
 		OperationTypes: operationTypes,
 		Directives:     directives,
 		Loc:            p.loc(start),
+		Doc:            docComment,
 	}, nil
 }
 
@@ -801,7 +883,11 @@ func (p *Parser) parseOperationTypeDefinition() (interface{}, error) {
  */
 func (p *Parser) parseScalarTypeDefinition() (*ast.ScalarDefinition, error) {
 	start := p.tok.Start
-	_, err := p.expectKeyWord("scalar")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("scalar")
 	if err != nil {
 		return nil, err
 	}
@@ -814,9 +900,10 @@ func (p *Parser) parseScalarTypeDefinition() (*ast.ScalarDefinition, error) {
 		return nil, err
 	}
 	def := &ast.ScalarDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
 	}
 	return def, nil
 }
@@ -828,7 +915,11 @@ func (p *Parser) parseObjectTypeDefinition() (*ast.ObjectDefinition, error) {
 	docComment := p.leadComment
 
 	start := p.tok.Start
-	_, err := p.expectKeyWord("type")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("type")
 
 	if err != nil {
 		return nil, err
@@ -856,12 +947,13 @@ func (p *Parser) parseObjectTypeDefinition() (*ast.ObjectDefinition, error) {
 		}
 	}
 	return &ast.ObjectDefinition{
-		Name:       name,
-		Loc:        p.loc(start),
-		Interfaces: interfaces,
-		Directives: directives,
-		Fields:     fields,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Loc:         p.loc(start),
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Fields:      fields,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -894,6 +986,10 @@ func (p *Parser) parseFieldDefinition() (interface{}, error) {
 	docComment := p.leadComment
 
 	start := p.tok.Start
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
 	name, err := p.parseName()
 	if err != nil {
 		return nil, err
@@ -915,13 +1011,14 @@ func (p *Parser) parseFieldDefinition() (interface{}, error) {
 		return nil, err
 	}
 	return &ast.FieldDefinition{
-		Name:       name,
-		Arguments:  args,
-		Type:       ttype,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Doc:        docComment,
-		Comment:    p.lineComment,
+		Description: description,
+		Name:        name,
+		Arguments:   args,
+		Type:        ttype,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Doc:         docComment,
+		Comment:     p.lineComment,
 	}, nil
 }
 
@@ -948,6 +1045,10 @@ func (p *Parser) parseArgumentDefs() ([]*ast.InputValueDefinition, error) {
 func (p *Parser) parseInputValueDef() (interface{}, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
 	name, err := p.parseName()
 	if err != nil {
 		return nil, err
@@ -977,6 +1078,7 @@ func (p *Parser) parseInputValueDef() (interface{}, error) {
 		return nil, err
 	}
 	return &ast.InputValueDefinition{
+		Description:  description,
 		Name:         name,
 		Type:         ttype,
 		DefaultValue: defaultValue,
@@ -990,7 +1092,11 @@ func (p *Parser) parseInputValueDef() (interface{}, error) {
 func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("interface")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("interface")
 	if err != nil {
 		return nil, err
 	}
@@ -998,6 +1104,10 @@ func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error
 	if err != nil {
 		return nil, err
 	}
+	interfaces, err := p.parseImplementsInterfaces()
+	if err != nil {
+		return nil, err
+	}
 	directives, err := p.parseDirectives()
 	if err != nil {
 		return nil, err
@@ -1013,11 +1123,13 @@ func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error
 		}
 	}
 	return &ast.InterfaceDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Fields:     fields,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Fields:      fields,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -1025,7 +1137,11 @@ func (p *Parser) parseInterfaceTypeDefinition() (*ast.InterfaceDefinition, error
 func (p *Parser) parseUnionTypeDefinition() (*ast.UnionDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("union")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("union")
 	if err != nil {
 		return nil, err
 	}
@@ -1046,12 +1162,13 @@ func (p *Parser) parseUnionTypeDefinition() (*ast.UnionDefinition, error) {
 		return nil, err
 	}
 	return &ast.UnionDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Types:      types,
-		Doc:        docComment,
-		Comment:    p.lineComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Types:       types,
+		Doc:         docComment,
+		Comment:     p.lineComment,
 	}, nil
 }
 
@@ -1075,7 +1192,11 @@ func (p *Parser) parseUnionMembers() ([]*ast.Named, error) {
 func (p *Parser) parseEnumTypeDefinition() (*ast.EnumDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("enum")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("enum")
 	if err != nil {
 		return nil, err
 	}
@@ -1098,17 +1219,22 @@ func (p *Parser) parseEnumTypeDefinition() (*ast.EnumDefinition, error) {
 		}
 	}
 	return &ast.EnumDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Values:     values,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Values:      values,
+		Doc:         docComment,
 	}, nil
 }
 
 func (p *Parser) parseEnumValueDefinition() (interface{}, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
 	name, err := p.parseName()
 	if err != nil {
 		return nil, err
@@ -1118,18 +1244,23 @@ func (p *Parser) parseEnumValueDefinition() (interface{}, error) {
 		return nil, err
 	}
 	return &ast.EnumValueDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Doc:        docComment,
-		Comment:    p.lineComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Doc:         docComment,
+		Comment:     p.lineComment,
 	}, nil
 }
 
 func (p *Parser) parseInputObjectTypeDefinition() (*ast.InputObjectDefinition, error) {
 	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("input")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("input")
 	if err != nil {
 		return nil, err
 	}
@@ -1152,11 +1283,12 @@ func (p *Parser) parseInputObjectTypeDefinition() (*ast.InputObjectDefinition, e
 		}
 	}
 	return &ast.InputObjectDefinition{
-		Name:       name,
-		Directives: directives,
-		Loc:        p.loc(start),
-		Fields:     fields,
-		Doc:        docComment,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         p.loc(start),
+		Fields:      fields,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -1167,13 +1299,35 @@ func (p *Parser) parseTypeExtensionDefinition() (*ast.TypeExtensionDefinition, e
 		return nil, err
 	}
 
-	definition, err := p.parseObjectTypeDefinition()
+	var definition ast.TypeDefinition
+	switch p.tok.Value {
+	case "scalar":
+		definition, err = p.parseScalarTypeDefinition()
+	case "type":
+		definition, err = p.parseObjectTypeDefinition()
+	case "interface":
+		definition, err = p.parseInterfaceTypeDefinition()
+	case "union":
+		definition, err = p.parseUnionTypeDefinition()
+	case "enum":
+		definition, err = p.parseEnumTypeDefinition()
+	case "input":
+		definition, err = p.parseInputObjectTypeDefinition()
+	default:
+		return nil, p.unexpected(lexer.Token{})
+	}
 	if err != nil {
 		return nil, err
 	}
+	if obj, ok := definition.(*ast.ObjectDefinition); ok {
+		return &ast.TypeExtensionDefinition{
+			Loc:        p.loc(start),
+			Definition: obj,
+		}, nil
+	}
 	return &ast.TypeExtensionDefinition{
-		Loc:        p.loc(start),
-		Definition: definition,
+		Loc:       p.loc(start),
+		Extension: definition,
 	}, nil
 }
 
@@ -1182,8 +1336,13 @@ func (p *Parser) parseTypeExtensionDefinition() (*ast.TypeExtensionDefinition, e
  *   - directive @ Name ArgumentsDefinition? on DirectiveLocations
  */
 func (p *Parser) parseDirectiveDefinition() (*ast.DirectiveDefinition, error) {
+	docComment := p.leadComment
 	start := p.tok.Start
-	_, err := p.expectKeyWord("directive")
+	description, err := p.parseDescription()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expectKeyWord("directive")
 	if err != nil {
 		return nil, err
 	}
@@ -1209,10 +1368,12 @@ func (p *Parser) parseDirectiveDefinition() (*ast.DirectiveDefinition, error) {
 	}
 
 	return &ast.DirectiveDefinition{
-		Loc:       p.loc(start),
-		Name:      name,
-		Arguments: args,
-		Locations: locations,
+		Description: description,
+		Loc:         p.loc(start),
+		Name:        name,
+		Arguments:   args,
+		Locations:   locations,
+		Doc:         docComment,
 	}, nil
 }
 
@@ -1363,13 +1524,13 @@ func (p *Parser) consumeCommentGroup(n int) (comments *ast.CommentGroup, endline
 }
 
 // Determines if the next token is of a given kind
-func (p *Parser) peek(Kind int) bool {
+func (p *Parser) peek(Kind lexer.TokenKind) bool {
 	return p.tok.Kind == Kind
 }
 
 // If the next token is of the given kind, return true after advancing
 // the parser. Otherwise, do not change the parser state and return false.
-func (p *Parser) skip(Kind int) (bool, error) {
+func (p *Parser) skip(Kind lexer.TokenKind) (bool, error) {
 	if p.tok.Kind == Kind {
 		return true, p.advance()
 	}
@@ -1378,7 +1539,7 @@ func (p *Parser) skip(Kind int) (bool, error) {
 
 // If the next token is of the given kind, return that token after advancing
 // the parser. Otherwise, do not change the parser state and return error.
-func (p *Parser) expect(kind int) (lexer.Token, error) {
+func (p *Parser) expect(kind lexer.TokenKind) (lexer.Token, error) {
 	token := p.tok
 	if token.Kind == kind {
 		return token, p.advance()
@@ -1413,7 +1574,7 @@ func (p *Parser) unexpected(atToken lexer.Token) error {
 // the parseFn. This list begins with a lex token of openKind
 // and ends with a lex token of closeKind. Advances the parser
 // to the next lex token after the closing token.
-func (p *Parser) any(openKind int, parseFn parseFn, closeKind int) ([]interface{}, error) {
+func (p *Parser) any(openKind lexer.TokenKind, parseFn parseFn, closeKind lexer.TokenKind) ([]interface{}, error) {
 	if _, err := p.expect(openKind); err != nil {
 		return nil, err
 	}
@@ -1437,7 +1598,7 @@ func (p *Parser) any(openKind int, parseFn parseFn, closeKind int) ([]interface{
 // the parseFn. This list begins with a lex token of openKind
 // and ends with a lex token of closeKind. Advances the parser
 // to the next lex token after the closing token.
-func (p *Parser) many(openKind int, parseFn parseFn, closeKind int) ([]interface{}, error) {
+func (p *Parser) many(openKind lexer.TokenKind, parseFn parseFn, closeKind lexer.TokenKind) ([]interface{}, error) {
 	_, err := p.expect(openKind)
 	if err != nil {
 		return nil, err
@@ -135,6 +135,44 @@ func TestParseProvidesUsefulErrorsWhenUsingSource(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
+func TestParseReportsErrorsRelativeToLocationOffset(t *testing.T) {
+	src := source.NewWithLocation("GraphQL", "{\n  field(\n}", "query.go", source.LocationOffset{Line: 42, Column: 15})
+	_, err := Parse(ParseParams{Source: src})
+	expectedError := &gqlerrors.Error{
+		Message: `Syntax Error query.go (44:1) Expected Name, found }
+
+43:   field(
+44: }
+    ^
+`,
+		Positions: []int{11},
+		Locations: []location.SourceLocation{{Line: 44, Column: 1}},
+	}
+	checkError(t, err, expectedError)
+
+	_, err = Parse(ParseParams{Source: source.NewWithLocation("GraphQL", "{ field: {} }", "query.go", source.LocationOffset{Line: 42, Column: 15})})
+	checkErrorMessage(t, err, `Syntax Error query.go (42:24) Expected Name, found {`)
+}
+
+func TestParseReportsErrorsInConcatenatedSourcesByOrigin(t *testing.T) {
+	src := source.Concat("schema",
+		source.New("a.graphql", "type A {\n  b: B\n}"),
+		source.New("b.graphql", "type B {\n  a: A\n  c(\n}"),
+	)
+	_, err := Parse(ParseParams{Source: src})
+	expectedError := &gqlerrors.Error{
+		Message: `Syntax Error b.graphql (4:1) Expected Name, found }
+
+3:   c(
+4: }
+   ^
+`,
+		Positions: []int{21},
+		Locations: []location.SourceLocation{{Line: 4, Column: 1, Source: "b.graphql"}},
+	}
+	checkError(t, err, expectedError)
+}
+
 func TestParsesVariableInlineValues(t *testing.T) {
 	source := `{ field(complex: { a: { b: [ $var ] } }) }`
 	// should not return error
@@ -171,13 +209,26 @@ func TestDoesNotAcceptFragmentsSpreadOfOn(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
-func TestDoesNotAllowNullAsValue(t *testing.T) {
-	test := errorMessageTest{
-		`{ fieldWithNullableStringInput(input: null) }'`,
-		`Syntax Error GraphQL (1:39) Unexpected Name "null"`,
-		false,
+func TestAllowsNullAsValue(t *testing.T) {
+	doc, err := Parse(ParseParams{Source: `{ fieldWithNullableStringInput(input: null) }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	if _, ok := field.Arguments[0].Value.(*ast.NullValue); !ok {
+		t.Fatalf("expected a null value, got %T", field.Arguments[0].Value)
+	}
+}
+
+func TestAllowsNullAsDefaultValue(t *testing.T) {
+	doc, err := Parse(ParseParams{Source: `query ($a: String = null) { a }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	def := doc.Definitions[0].(*ast.OperationDefinition).VariableDefinitions[0]
+	if _, ok := def.DefaultValue.(*ast.NullValue); !ok {
+		t.Fatalf("expected a null value, got %T", def.DefaultValue)
 	}
-	testErrorMessage(t, test)
 }
 
 func TestParsesMultiByteCharacters_Unicode(t *testing.T) {
@@ -887,6 +938,105 @@ func toError(err error) *gqlerrors.Error {
 	}
 }
 
+// describeType describes how a type reference is wrapped along with the
+// locations of each node.
+func describeType(ttype ast.Type) string {
+	switch ttype := ttype.(type) {
+	case *ast.Named:
+		return fmt.Sprintf("Named(%s)@%d-%d", ttype.Name.Value, ttype.Loc.Start, ttype.Loc.End)
+	case *ast.List:
+		return fmt.Sprintf("List(%s)@%d-%d", describeType(ttype.Type), ttype.Loc.Start, ttype.Loc.End)
+	case *ast.NonNull:
+		return fmt.Sprintf("NonNull(%s)@%d-%d", describeType(ttype.Type), ttype.Loc.Start, ttype.Loc.End)
+	}
+	return fmt.Sprintf("%T", ttype)
+}
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{`Int`, `Named(Int)@0-3`},
+		{`[String]`, `List(Named(String)@1-7)@0-8`},
+		{`[Int!]!`, `NonNull(List(NonNull(Named(Int)@1-4)@1-5)@0-6)@0-7`},
+		{` [ Int ! ] `, `List(NonNull(Named(Int)@3-6)@3-8)@1-10`},
+	}
+	for _, test := range tests {
+		ttype, err := ParseType(test.body)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %v", test.body, err)
+		}
+		if described := describeType(ttype); described != test.expected {
+			t.Fatalf("Unexpected type parsing %s, expected %s, got %s", test.body, test.expected, described)
+		}
+	}
+
+	for body, message := range map[string]string{
+		`[Int`:    `Syntax Error GraphQL (1:5) Expected ], found EOF`,
+		`Int!!`:   `Syntax Error GraphQL (1:5) Expected EOF, found !`,
+		`Int Int`: `Syntax Error GraphQL (1:5) Expected EOF, found Name "Int"`,
+	} {
+		_, err := ParseType(body)
+		checkErrorMessage(t, err, message)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected interface{}
+	}{
+		{`{a: 1, b: [true, null]}`, &ast.ObjectValue{}},
+		{`RED`, &ast.EnumValue{}},
+		{`$var`, &ast.Variable{}},
+		{`"str"`, &ast.StringValue{}},
+		{`-1.5`, &ast.FloatValue{}},
+		{`null`, &ast.NullValue{}},
+	}
+	for _, test := range tests {
+		value, err := ParseValue(test.body)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %v", test.body, err)
+		}
+		if reflect.TypeOf(value) != reflect.TypeOf(test.expected) {
+			t.Fatalf("Unexpected value parsing %s, expected %T, got %T", test.body, test.expected, value)
+		}
+		if printed := printer.Print(value); printed != test.body {
+			t.Fatalf("Unexpected value parsing %s, got %v", test.body, printed)
+		}
+	}
+
+	value, err := ParseValue(`{a: 1, b: [true, null]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := value.(*ast.ObjectValue).Fields
+	if len(fields) != 2 || fields[0].Name.Value != "a" || fields[1].Name.Value != "b" {
+		t.Fatalf("Unexpected fields %s", pretty.Sprint(fields))
+	}
+	if list, ok := fields[1].Value.(*ast.ListValue); !ok || len(list.Values) != 2 {
+		t.Fatalf("Expected a list of 2 values, got %s", pretty.Sprint(fields[1].Value))
+	}
+	value, err = ParseValue(`$var`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := value.(*ast.Variable).Name.Value; name != "var" {
+		t.Fatalf("Expected variable var, got %s", name)
+	}
+
+	for body, message := range map[string]string{
+		`{a: 1} }`: `Syntax Error GraphQL (1:8) Expected EOF, found }`,
+		`1 2`:      `Syntax Error GraphQL (1:3) Expected EOF, found Int "2"`,
+		`[1`:       `Syntax Error GraphQL (1:3) Unexpected EOF`,
+		``:         `Syntax Error GraphQL (1:1) Unexpected EOF`,
+	} {
+		_, err := ParseValue(body)
+		checkErrorMessage(t, err, message)
+	}
+}
+
 func TestBadQueryHang(t *testing.T) {
 	Parse(ParseParams{
 		Source: source.New("GraphQL", "{g(d:[d[\xb9\x19 rp\\�{\xef\xbf\xbd2~� c"),
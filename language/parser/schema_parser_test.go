@@ -69,6 +69,57 @@ type Hello {
 	}
 }
 
+func TestSchemaParser_SimpleTypeWithDescriptions(t *testing.T) {
+	body := `
+"""
+The world
+"""
+type Hello {
+  "Greeting" world: String
+}`
+	astDoc := parse(t, body)
+	expected := &ast.Document{
+		Loc: testLoc(1, 60),
+		Definitions: []ast.Node{
+			&ast.ObjectDefinition{
+				Loc: testLoc(1, 60),
+				Description: &ast.StringValue{
+					Value: "The world",
+					Block: true,
+					Loc:   testLoc(1, 18),
+				},
+				Name: &ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(24, 29),
+				},
+				Fields: []*ast.FieldDefinition{
+					{
+						Loc: testLoc(34, 58),
+						Description: &ast.StringValue{
+							Value: "Greeting",
+							Loc:   testLoc(34, 44),
+						},
+						Name: &ast.Name{
+							Value: "world",
+							Loc:   testLoc(45, 50),
+						},
+						Type: &ast.Named{
+							Loc: testLoc(52, 58),
+							Name: &ast.Name{
+								Value: "String",
+								Loc:   testLoc(52, 58),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", jsonString(expected), jsonString(astDoc))
+	}
+}
+
 func TestSchemaParser_SimpleExtension(t *testing.T) {
 	body := `
 extend type Hello {
@@ -111,6 +162,41 @@ extend type Hello {
 	}
 }
 
+func TestSchemaParser_ExtensionsOfAllTypes(t *testing.T) {
+	body := `
+extend scalar Time @deprecated
+extend interface Node { id: ID }
+extend union Result = Photo
+extend enum Color { RED }
+extend input Point { x: Int }`
+	astDoc := parse(t, body)
+	var got []string
+	for _, def := range astDoc.Definitions {
+		ext, ok := def.(*ast.TypeExtensionDefinition)
+		if !ok {
+			t.Fatalf("expected *ast.TypeExtensionDefinition, got %T", def)
+		}
+		if ext.Definition != nil {
+			t.Fatalf("expected no object definition, got %v", ext.Definition)
+		}
+		got = append(got, reflect.TypeOf(ext.ExtendedType()).String())
+	}
+	expected := []string{
+		"*ast.ScalarDefinition",
+		"*ast.InterfaceDefinition",
+		"*ast.UnionDefinition",
+		"*ast.EnumDefinition",
+		"*ast.InputObjectDefinition",
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("unexpected extension definitions, expected: %v, got: %v", expected, got)
+	}
+
+	if _, err := Parse(ParseParams{Source: "extend schema { query: Q }"}); err == nil {
+		t.Fatal("expected error extending a schema")
+	}
+}
+
 func TestSchemaParser_SimpleNonNullType(t *testing.T) {
 	body := `
 type Hello {
@@ -324,6 +410,43 @@ interface Hello {
 	}
 }
 
+func TestSchemaParser_InterfaceInheritingInterfaces(t *testing.T) {
+	body := `interface Hello implements World & Node { }`
+	astDoc := parse(t, body)
+	expected := &ast.Document{
+		Loc: testLoc(0, 43),
+		Definitions: []ast.Node{
+			&ast.InterfaceDefinition{
+				Loc: testLoc(0, 43),
+				Name: &ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(10, 15),
+				},
+				Interfaces: []*ast.Named{
+					{
+						Name: &ast.Name{
+							Value: "World",
+							Loc:   testLoc(27, 32),
+						},
+						Loc: testLoc(27, 32),
+					},
+					{
+						Name: &ast.Name{
+							Value: "Node",
+							Loc:   testLoc(35, 39),
+						},
+						Loc: testLoc(35, 39),
+					},
+				},
+				Fields: []*ast.FieldDefinition{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %s, got: %s", jsonString(expected), jsonString(astDoc))
+	}
+}
+
 func TestSchemaParser_SimpleFieldWithArg(t *testing.T) {
 	body := `
 type Hello {
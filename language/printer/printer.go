@@ -38,7 +38,56 @@ func indent(s string) string {
 	return strings.Replace(s, "\n", "\n  ", -1)
 }
 
+// compactJoin joins the non-empty strings, separating them by a space only
+// where one is needed to keep adjacent names and numbers apart.
+func compactJoin(str []string) string {
+	var b strings.Builder
+	for _, s := range str {
+		if s == "" {
+			continue
+		}
+		if b.Len() > 0 && isNameChar(b.String()[b.Len()-1]) && isNameChar(s[0]) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+func isTypeSystemDefinition(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.SchemaDefinition, *ast.ScalarDefinition, *ast.ObjectDefinition, *ast.InterfaceDefinition,
+		*ast.UnionDefinition, *ast.EnumDefinition, *ast.InputObjectDefinition, *ast.TypeExtensionDefinition,
+		*ast.DirectiveDefinition:
+		return true
+	}
+	return false
+}
+
 type walker struct {
+	compact bool
+}
+
+// join joins the strings with sep, or with as little whitespace as possible
+// when printing compactly.
+func (w *walker) join(str []string, sep string) string {
+	if w.compact {
+		return compactJoin(str)
+	}
+	return join(str, sep)
+}
+
+// punct returns the separator sep, stripped of its whitespace when printing
+// compactly. It must contain punctuation.
+func (w *walker) punct(sep string) string {
+	if w.compact {
+		return strings.TrimSpace(sep)
+	}
+	return sep
 }
 
 func (w *walker) walkASTSlice(sl interface{}) []string {
@@ -56,11 +105,19 @@ func (w *walker) walkASTSlice(sl interface{}) []string {
 
 func (w *walker) walkASTSliceAndJoin(sl interface{}, sep string) string {
 	strs := w.walkASTSlice(sl)
+	if w.compact {
+		if sep = strings.TrimSpace(sep); sep == "" {
+			return compactJoin(strs)
+		}
+	}
 	return strings.Join(strs, sep)
 }
 
 func (w *walker) walkASTSliceAndBlock(sl interface{}) string {
 	strs := w.walkASTSlice(sl)
+	if w.compact {
+		return "{" + compactJoin(strs) + "}"
+	}
 	return block(strs)
 }
 
@@ -78,6 +135,11 @@ func (w *walker) walkAST(root ast.Node) string {
 	if root == nil {
 		return ""
 	}
+	if w.compact && isTypeSystemDefinition(root) {
+		// Comments in type system definitions run to the end of the line so
+		// they're never compacted.
+		return (&walker{}).walkAST(root)
+	}
 
 	switch node := root.(type) {
 	case *ast.Name:
@@ -88,6 +150,9 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.Variable:
 		return "$" + node.Name.Value
 	case *ast.Document:
+		if w.compact {
+			return w.walkCompactDefinitions(node.Definitions)
+		}
 		return w.walkASTSliceAndJoin(node.Definitions, "\n\n") + "\n"
 	case *ast.OperationDefinition:
 		name := w.walkAST(node.Name)
@@ -97,7 +162,7 @@ func (w *walker) walkAST(root ast.Node) string {
 		if name == "" && directives == "" && varDefs == "" && node.Operation == ast.OperationTypeQuery {
 			return selectionSet
 		}
-		return join([]string{
+		return w.join([]string{
 			node.Operation,
 			join([]string{name, varDefs}, ""),
 			directives,
@@ -107,7 +172,7 @@ func (w *walker) walkAST(root ast.Node) string {
 		variable := w.walkAST(node.Variable)
 		ttype := w.walkAST(node.Type)
 		defaultValue := w.walkAST(node.DefaultValue)
-		return variable + ": " + ttype + wrap(" = ", defaultValue, "")
+		return variable + w.punct(": ") + ttype + wrap(w.punct(" = "), defaultValue, "")
 	case *ast.SelectionSet:
 		if node == nil {
 			return ""
@@ -119,9 +184,9 @@ func (w *walker) walkAST(root ast.Node) string {
 		args := w.walkASTSliceAndJoin(node.Arguments, ", ")
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		selectionSet := w.walkAST(node.SelectionSet)
-		return join(
+		return w.join(
 			[]string{
-				wrap("", alias, ": ") + name + wrap("(", args, ")"),
+				wrap("", alias, w.punct(": ")) + name + wrap("(", args, ")"),
 				directives,
 				selectionSet,
 			},
@@ -129,15 +194,18 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.Argument:
 		name := w.walkAST(node.Name)
 		value := w.walkAST(node.Value)
-		return name + ": " + value
+		return name + w.punct(": ") + value
 	case *ast.FragmentSpread:
 		name := w.walkAST(node.Name)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return "..." + name + wrap(" ", directives, "")
+		return w.join([]string{"..." + name, directives}, " ")
 	case *ast.InlineFragment:
 		typeCondition := w.walkAST(node.TypeCondition)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		selectionSet := w.walkAST(node.SelectionSet)
+		if w.compact {
+			return compactJoin([]string{"...", wrap("on ", typeCondition, ""), directives, selectionSet})
+		}
 		if typeCondition == "" {
 			return "... " + wrap("", directives, " ") + selectionSet
 		} else {
@@ -148,6 +216,9 @@ func (w *walker) walkAST(root ast.Node) string {
 		typeCondition := w.walkAST(node.TypeCondition)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		selectionSet := w.walkAST(node.SelectionSet)
+		if w.compact {
+			return compactJoin([]string{"fragment", name, "on", typeCondition, directives, selectionSet})
+		}
 		return "fragment " + name + " on " + typeCondition + " " + wrap("", directives, " ") + selectionSet
 	case *ast.IntValue:
 		return node.Value
@@ -157,6 +228,8 @@ func (w *walker) walkAST(root ast.Node) string {
 		return strconv.Quote(node.Value)
 	case *ast.BooleanValue:
 		return strconv.FormatBool(node.Value)
+	case *ast.NullValue:
+		return "null"
 	case *ast.EnumValue:
 		return node.Value
 	case *ast.ListValue:
@@ -166,7 +239,7 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.ObjectField:
 		name := w.walkAST(node.Name)
 		value := w.walkAST(node.Value)
-		return name + ": " + value
+		return name + w.punct(": ") + value
 	case *ast.Directive:
 		name := w.walkAST(node.Name)
 		args := w.walkASTSliceAndJoin(node.Arguments, ", ")
@@ -183,26 +256,26 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.SchemaDefinition:
 		operationTypesBlock := w.walkASTSliceAndBlock(node.OperationTypes)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return join([]string{"schema", directives, operationTypesBlock}, " ")
+		return join([]string{description(node.Description) + "schema", directives, operationTypesBlock}, " ")
 	case *ast.OperationTypeDefinition:
 		return fmt.Sprintf("%v: %v", node.Operation, node.Type)
 	case *ast.ScalarDefinition:
 		name := w.walkAST(node.Name)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return join([]string{"scalar", name, directives}, " ")
+		return join([]string{description(node.Description) + "scalar", name, directives}, " ")
 	case *ast.ObjectDefinition:
 		name := w.walkAST(node.Name)
 		interfaces := w.walkASTSliceAndJoin(node.Interfaces, ", ")
 		fields := w.walkASTSliceAndBlock(node.Fields)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
-		return join([]string{joinComments(node.Doc, "", "\n") + "type", name, wrap("implements ", interfaces, ""), directives, fields}, " ")
+		return join([]string{joinComments(node.Doc, "", "\n") + description(node.Description) + "type", name, wrap("implements ", interfaces, ""), directives, fields}, " ")
 	case *ast.FieldDefinition:
 		name := w.walkAST(node.Name)
 		ttype := w.walkAST(node.Type)
 		args := w.walkASTSliceAndJoin(node.Arguments, ", ")
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + name + wrap("(", args, ")") + ":",
+			joinComments(node.Doc, "", "\n") + description(node.Description) + name + wrap("(", args, ")") + ":",
 			ttype, directives, joinComments(node.Comment, "", "")}, " ")
 	case *ast.InputValueDefinition:
 		name := w.walkAST(node.Name)
@@ -210,41 +283,42 @@ func (w *walker) walkAST(root ast.Node) string {
 		defaultValue := w.walkAST(node.DefaultValue)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + name + ":",
+			joinComments(node.Doc, "", "\n") + description(node.Description) + name + ":",
 			ttype, wrap("= ", defaultValue, ""), directives + joinComments(node.Comment, "", "")}, " ")
 	case *ast.InterfaceDefinition:
 		name := w.walkAST(node.Name)
+		interfaces := w.walkASTSliceAndJoin(node.Interfaces, ", ")
 		fields := w.walkASTSliceAndBlock(node.Fields)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "interface",
-			name, directives, fields}, " ")
+			joinComments(node.Doc, "", "\n") + description(node.Description) + "interface",
+			name, wrap("implements ", interfaces, ""), directives, fields}, " ")
 	case *ast.UnionDefinition:
 		name := w.walkAST(node.Name)
 		types := w.walkASTSliceAndJoin(node.Types, " | ")
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "union",
+			joinComments(node.Doc, "", "\n") + description(node.Description) + "union",
 			name, directives, "=", types + joinComments(node.Comment, " ", "")}, " ")
 	case *ast.EnumDefinition:
 		name := w.walkAST(node.Name)
 		values := w.walkASTSliceAndBlock(node.Values)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "enum",
+			joinComments(node.Doc, "", "\n") + description(node.Description) + "enum",
 			name, directives, values}, " ")
 	case *ast.EnumValueDefinition:
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + w.walkAST(node.Name), directives, joinComments(node.Comment, "", "")}, " ")
+			joinComments(node.Doc, "", "\n") + description(node.Description) + w.walkAST(node.Name), directives, joinComments(node.Comment, "", "")}, " ")
 	case *ast.InputObjectDefinition:
 		name := w.walkAST(node.Name)
 		fields := w.walkASTSliceAndBlock(node.Fields)
 		directives := w.walkASTSliceAndJoin(node.Directives, " ")
 		return join([]string{
-			joinComments(node.Doc, "", "\n") + "input", name, directives, fields}, " ")
+			joinComments(node.Doc, "", "\n") + description(node.Description) + "input", name, directives, fields}, " ")
 	case *ast.TypeExtensionDefinition:
-		return "extend " + w.walkAST(node.Definition)
+		return "extend " + w.walkAST(node.ExtendedType())
 	case *ast.CommentGroup:
 		lines := make([]string, len(node.List))
 		for i, c := range node.List {
@@ -255,7 +329,7 @@ func (w *walker) walkAST(root ast.Node) string {
 	case *ast.DirectiveDefinition:
 		name := w.walkAST(node.Name)
 		args := wrap("(", w.walkASTSliceAndJoin(node.Arguments, ", "), ")")
-		return fmt.Sprintf("directive @%v%v on %v", name, args, w.walkASTSliceAndJoin(node.Locations, " | "))
+		return fmt.Sprintf("%vdirective @%v%v on %v", description(node.Description), name, args, w.walkASTSliceAndJoin(node.Locations, " | "))
 	case ast.Type:
 		return node.String()
 	case ast.Value:
@@ -264,6 +338,22 @@ func (w *walker) walkAST(root ast.Node) string {
 	return fmt.Sprintf("[Unknown node type %T]", root)
 }
 
+// description returns the description of a type system definition followed by
+// a newline, or an empty string if it has none.
+func description(desc *ast.StringValue) string {
+	if desc == nil {
+		return ""
+	}
+	if !desc.Block {
+		return strconv.Quote(desc.Value) + "\n"
+	}
+	value := strings.Replace(desc.Value, `"""`, `\"""`, -1)
+	if strings.Contains(value, "\n") || strings.HasSuffix(value, `"`) {
+		return `"""` + "\n" + value + "\n" + `"""` + "\n"
+	}
+	return `"""` + value + `"""` + "\n"
+}
+
 func joinComments(cg *ast.CommentGroup, prefix, suffix string) string {
 	if cg == nil {
 		return ""
@@ -275,6 +365,44 @@ func joinComments(cg *ast.CommentGroup, prefix, suffix string) string {
 	return prefix + strings.Join(lines, "\n") + suffix
 }
 
-func Print(node ast.Node) string {
-	return (&walker{}).walkAST(node)
+// walkCompactDefinitions prints the definitions of a document compactly. Type
+// system definitions are kept on lines of their own.
+func (w *walker) walkCompactDefinitions(defs []ast.Node) string {
+	var b strings.Builder
+	prevTypeSystem := false
+	for _, def := range defs {
+		s := w.walkAST(def)
+		if s == "" {
+			continue
+		}
+		typeSystem := isTypeSystemDefinition(def)
+		if b.Len() > 0 {
+			if typeSystem || prevTypeSystem {
+				b.WriteByte('\n')
+			} else if isNameChar(b.String()[b.Len()-1]) && isNameChar(s[0]) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(s)
+		prevTypeSystem = typeSystem
+	}
+	return b.String()
+}
+
+// PrintOptions controls how Print formats its output.
+type PrintOptions struct {
+	// Compact removes all insignificant whitespace and newlines, such as to
+	// minimize the size of a query sent over the network. Type system
+	// definitions are still printed in full.
+	Compact bool
+}
+
+// Print returns the GraphQL source of the node. It's formatted for reading
+// unless options asking for compact output are given.
+func Print(node ast.Node, opts ...PrintOptions) string {
+	w := &walker{}
+	for _, o := range opts {
+		w.compact = o.Compact
+	}
+	return w.walkAST(node)
 }
@@ -177,6 +177,31 @@ fragment frag on Follower {
 	}
 }
 
+func TestPrintsKitchenSinkCompactly(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+
+	astDoc := parse(t, string(b))
+	expected := `query namedQuery($foo:ComplexFooType,$bar:Bar=DefaultBarValue){customUser:user(id:[987,654]){id...on User@defer{field2{id alias:field1(first:10,after:$foo)@include(if:$foo){id...frag}}}...@skip(unless:$foo){id}...{id}}}` +
+		`mutation favPost{fav(post:123)@defer{post{id}}}` +
+		`subscription PostFavSubscription($input:StoryLikeSubscribeInput){postFavSubscribe(input:$input){post{favers{count}favSentence{text}}}}` +
+		`fragment frag on Follower{foo(size:$size,bar:$b,obj:{key:"value"})}` +
+		`{unnamed(truthyVal:true,falseyVal:false)query}`
+
+	compact := printer.Print(astDoc, printer.PrintOptions{Compact: true})
+	if compact != expected {
+		t.Fatalf("Unexpected result, expected\n%s\ngot\n%s", expected, compact)
+	}
+
+	// The compact output is the same document as the pretty one.
+	pretty := printer.Print(astDoc)
+	if reprinted := printer.Print(parse(t, compact)); reprinted != pretty {
+		t.Fatalf("Unexpected result, expected\n%s\ngot\n%s", pretty, reprinted)
+	}
+}
+
 func TestComments(t *testing.T) {
 	source := `# Unconnected comment
 # part of the same group
@@ -140,3 +140,70 @@ directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 		t.Fatalf("Unexpected result")
 	}
 }
+
+func TestSchemaPrinter_PrintsDescriptions(t *testing.T) {
+	source := `"""
+A type with
+a long description
+"""
+type Foo {
+  "A field"
+  bar(
+    """An argument"""
+    baz: Int
+  ): String
+}
+
+"""Colors"""
+enum Color {
+  """
+  Ends with a "quote"
+  """
+  RED
+}
+`
+	expected := `"""
+A type with
+a long description
+"""
+type Foo {
+  "A field"
+  bar("""An argument"""
+  baz: Int): String
+}
+
+"""Colors"""
+enum Color {
+  """
+  Ends with a "quote"
+  """
+  RED
+}
+`
+	astDoc := parse(t, source)
+	results := printer.Print(astDoc)
+	if results != expected {
+		t.Fatalf("Unexpected result, expected\n%s\ngot\n%s", expected, results)
+	}
+	// Printing preserves the descriptions.
+	if reprinted := printer.Print(parse(t, results)); reprinted != expected {
+		t.Fatalf("Unexpected result, expected\n%s\ngot\n%s", expected, reprinted)
+	}
+}
+
+func TestSchemaPrinter_PrintsInterfacesImplementingInterfaces(t *testing.T) {
+	source := `interface Entity implements Node & Named @onInterface {
+  id: ID!
+  name: String
+}
+`
+	expected := `interface Entity implements Node, Named @onInterface {
+  id: ID!
+  name: String
+}
+`
+	results := printer.Print(parse(t, source))
+	if results != expected {
+		t.Fatalf("Unexpected result, expected\n%s\ngot\n%s", expected, results)
+	}
+}
@@ -1,12 +1,27 @@
 package source
 
-import "sort"
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
 
 // Source is used with the lexer.
 type Source struct {
 	body       string
 	name       string
+	filePath   string
+	offset     LocationOffset
 	linesIndex []int // offset for each line: start offset of line n -> linesIndex[n-1] when line numbers start at 1
+	parts      []part
+}
+
+// part is a source whose body was concatenated into the body of another
+// source starting at the rune offset start.
+type part struct {
+	source *Source
+	start  int
 }
 
 // Position represents a rune position in the source.
@@ -16,6 +31,13 @@ type Position struct {
 	Column int // column number, starting at 1 (byte count)
 }
 
+// LocationOffset is the line and column, both starting at 1, at which the
+// body of a source begins within the file that contains it.
+type LocationOffset struct {
+	Line   int
+	Column int
+}
+
 // New initializes a new source with the provided name and body.
 func New(name, body string) *Source {
 	return &Source{
@@ -24,11 +46,96 @@ func New(name, body string) *Source {
 	}
 }
 
+// NewWithLocation initializes a new source whose body is embedded in the file
+// at filePath starting at offset (e.g. a query in a Go string literal), so that
+// locations are reported relative to that file.
+func NewWithLocation(name, body, filePath string, offset LocationOffset) *Source {
+	return &Source{
+		name:     name,
+		body:     body,
+		filePath: filePath,
+		offset:   offset,
+	}
+}
+
+// NewFromFile reads the file at path into a new source. The path is used as
+// the source's name and file path, so errors name the file they come from.
+func NewFromFile(path string) (*Source, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{
+		name:     path,
+		body:     string(body),
+		filePath: path,
+	}, nil
+}
+
+// Concat initializes a new source with the bodies of the given sources, each
+// starting on a new line, so that e.g. a schema split across files can be
+// parsed as a single document. Syntax errors and locations in the body are
+// reported relative to the source they come from, and name it. See Origin.
+func Concat(name string, sources ...*Source) *Source {
+	var body strings.Builder
+	parts := make([]part, 0, len(sources))
+	start := 0
+	for i, src := range sources {
+		if i > 0 {
+			body.WriteByte('\n')
+			start++
+		}
+		parts = append(parts, part{source: src, start: start})
+		body.WriteString(src.Body())
+		start += utf8.RuneCountInString(src.Body())
+	}
+	return &Source{
+		name:  name,
+		body:  body.String(),
+		parts: parts,
+	}
+}
+
+// Origin returns the source that the rune offset in the body comes from, and
+// the offset within that source's body. It's the source itself and the same
+// offset unless the source was created with Concat.
+func (s *Source) Origin(offset int) (*Source, int) {
+	if s == nil || len(s.parts) == 0 {
+		return s, offset
+	}
+	i := sort.Search(len(s.parts), func(i int) bool {
+		return s.parts[i].start > offset
+	}) - 1
+	if i < 0 {
+		i = 0
+	}
+	p := s.parts[i]
+	return p.source.Origin(offset - p.start)
+}
+
 // Name returns the name of the source
 func (s *Source) Name() string {
 	return s.name
 }
 
+// FilePath returns the path of the file containing the source, if any
+func (s *Source) FilePath() string {
+	return s.filePath
+}
+
+// LocationOffset returns the location at which the body starts in its file.
+// It's 1:1 unless the source was created with NewWithLocation.
+func (s *Source) LocationOffset() LocationOffset {
+	offset := s.offset
+	if offset.Line < 1 {
+		offset.Line = 1
+	}
+	if offset.Column < 1 {
+		offset.Column = 1
+	}
+	return offset
+}
+
 // Body returns the body of the source
 func (s *Source) Body() string {
 	return s.body
@@ -1,6 +1,9 @@
 package source
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -26,6 +29,19 @@ func TestSourcePosition(t *testing.T) {
 	}
 }
 
+func TestSourceLocationOffset(t *testing.T) {
+	if o := New("", "{}").LocationOffset(); o != (LocationOffset{Line: 1, Column: 1}) {
+		t.Errorf("New().LocationOffset() = %+v, expected 1:1", o)
+	}
+	src := NewWithLocation("", "{}", "query.go", LocationOffset{Line: 42, Column: 15})
+	if o := src.LocationOffset(); o != (LocationOffset{Line: 42, Column: 15}) {
+		t.Errorf("LocationOffset() = %+v, expected 42:15", o)
+	}
+	if src.FilePath() != "query.go" {
+		t.Errorf("FilePath() = %q, expected %q", src.FilePath(), "query.go")
+	}
+}
+
 func TestStringToLineIndex(t *testing.T) {
 	cases := []struct {
 		st string
@@ -46,3 +62,63 @@ func TestStringToLineIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestNewFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "schema.graphql")
+	if err := ioutil.WriteFile(path, []byte("type Query {\n  a: Int\n}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	src, err := NewFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Name() != path {
+		t.Errorf("Name() = %q, expected %q", src.Name(), path)
+	}
+	if src.FilePath() != path {
+		t.Errorf("FilePath() = %q, expected %q", src.FilePath(), path)
+	}
+	if src.Body() != "type Query {\n  a: Int\n}\n" {
+		t.Errorf("Body() = %q", src.Body())
+	}
+	if _, err := NewFromFile(filepath.Join(dir, "missing.graphql")); !os.IsNotExist(err) {
+		t.Errorf("NewFromFile of a missing file returned %v, expected not exist error", err)
+	}
+}
+
+func TestConcatOrigin(t *testing.T) {
+	a := New("a.graphql", "type A")
+	b := New("b.graphql", "type \u00e9")
+	c := New("c.graphql", "type C")
+	src := Concat("schema", a, Concat("inner", b), c)
+	if src.Body() != "type A\ntype \u00e9\ntype C" {
+		t.Fatalf("Body() = %q", src.Body())
+	}
+	cases := []struct {
+		ix     int
+		source *Source
+		offset int
+	}{
+		{ix: 0, source: a, offset: 0},
+		{ix: 5, source: a, offset: 5},
+		{ix: 6, source: a, offset: 6},
+		{ix: 7, source: b, offset: 0},
+		{ix: 12, source: b, offset: 5},
+		{ix: 14, source: c, offset: 0},
+		{ix: 19, source: c, offset: 5},
+	}
+	for _, c := range cases {
+		s, offset := src.Origin(c.ix)
+		if s != c.source || offset != c.offset {
+			t.Errorf("Origin(%d) = %s:%d, expected %s:%d", c.ix, s.Name(), offset, c.source.Name(), c.offset)
+		}
+	}
+	if s, offset := a.Origin(3); s != a || offset != 3 {
+		t.Errorf("Origin(3) of a plain source = %s:%d, expected a.graphql:3", s.Name(), offset)
+	}
+}
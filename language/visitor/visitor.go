@@ -122,6 +122,7 @@ func visit(root ast.Node, visitorOpts *VisitorOptions, ancestors []ast.Node, par
 	case *ast.FloatValue:
 	case *ast.StringValue:
 	case *ast.BooleanValue:
+	case *ast.NullValue:
 	case *ast.EnumValue:
 	case *ast.ListValue:
 		for _, n := range root.Values {
@@ -174,6 +175,9 @@ func visit(root ast.Node, visitorOpts *VisitorOptions, ancestors []ast.Node, par
 		}
 	case *ast.InterfaceDefinition:
 		visit(root.Name, visitorOpts, p.Ancestors, root)
+		for _, n := range root.Interfaces {
+			visit(n, visitorOpts, p.Ancestors, root)
+		}
 		for _, n := range root.Directives {
 			visit(n, visitorOpts, p.Ancestors, root)
 		}
@@ -215,7 +219,7 @@ func visit(root ast.Node, visitorOpts *VisitorOptions, ancestors []ast.Node, par
 			visit(n, visitorOpts, p.Ancestors, root)
 		}
 	case *ast.TypeExtensionDefinition:
-		visit(root.Definition, visitorOpts, p.Ancestors, root)
+		visit(root.ExtendedType(), visitorOpts, p.Ancestors, root)
 	case *ast.DirectiveDefinition:
 		visit(root.Name, visitorOpts, p.Ancestors, root)
 		for _, n := range root.Arguments {
@@ -1,6 +1,7 @@
 package graphql_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -268,6 +269,7 @@ func TestLists_NonNullListOfNullableObjectsReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -338,6 +340,7 @@ func TestLists_NonNullListOfNullableFunc_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -434,6 +437,7 @@ func TestLists_NullableListOfNonNullObjects_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -499,6 +503,7 @@ func TestLists_NullableListOfNonNullFunc_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -610,6 +615,7 @@ func TestLists_NonNullListOfNonNullObjects_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -631,6 +637,7 @@ func TestLists_NonNullListOfNonNullObjects_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -682,6 +689,7 @@ func TestLists_NonNullListOfNonNullFunc_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -708,6 +716,7 @@ func TestLists_NonNullListOfNonNullFunc_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -781,8 +790,85 @@ func TestLists_UserErrorExpectIterableButDidNotGetOne(t *testing.T) {
 				Type:      "INTERNAL",
 				Message:   "User Error: expected iterable, but did not find one for field DataType.test.",
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"nest", "test"},
 			},
 		},
 	}
 	checkList(t, ttype, data, expected)
 }
+
+func TestLists_ResolverReturningAChannelIsCollectedIntoAList(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewList(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			ch := make(chan interface{})
+			go func() {
+				defer close(ch)
+				for i := 1; i <= 3; i++ {
+					ch <- i
+				}
+			}()
+			return ch, nil
+		},
+	})
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"test": []interface{}{1, 2, 3},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestLists_ResolverReturningANilChannelIsNull(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewList(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var ch chan int
+			return ch, nil
+		},
+	})
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"test": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestLists_ResolverReturningAChannelStopsWhenTheContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewList(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			ch := make(chan int)
+			go func() {
+				ch <- 1
+				cancel()
+			}()
+			return ch, nil
+		},
+	})
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test }`,
+		Context:       ctx,
+	})
+	if len(result.Errors) == 0 {
+		t.Fatal("Expected an error when the context is cancelled")
+	}
+}
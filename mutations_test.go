@@ -1,6 +1,7 @@
 package graphql_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -250,3 +251,173 @@ func TestMutations_EvaluatesMutationsCorrectlyInThePresenceOfAFailedMutation(t *
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestMutations_ResolversSeeTheResolvedSiblings(t *testing.T) {
+	seen := map[string]map[string]interface{}{}
+	incrementField := &graphql.Field{
+		Type: graphql.Int,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var siblings map[string]interface{}
+			if p.Info.ResolvedSiblings != nil {
+				siblings = make(map[string]interface{}, len(p.Info.ResolvedSiblings))
+				for k, v := range p.Info.ResolvedSiblings {
+					siblings[k] = v
+				}
+			}
+			seen[p.Info.Path[0].(string)] = siblings
+			return len(siblings) + 1, nil
+		},
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"increment": incrementField,
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"increment": incrementField,
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { first: increment second: increment }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	// The fields are resolved serially in the order they're selected, so the
+	// second sees the value of the first.
+	expectedData := map[string]interface{}{"first": 1, "second": 2}
+	if !reflect.DeepEqual(expectedData, result.Data) {
+		t.Fatalf("Unexpected data, Diff: %v", testutil.Diff(expectedData, result.Data))
+	}
+	expected := map[string]map[string]interface{}{
+		"first":  {},
+		"second": {"first": 1},
+	}
+	if !reflect.DeepEqual(expected, seen) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, seen))
+	}
+
+	// Fields selected by fragments are resolved where they're spread.
+	seen = map[string]map[string]interface{}{}
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { c: increment ...F a: increment } fragment F on Mutation { b: increment }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected = map[string]map[string]interface{}{
+		"c": {},
+		"b": {"c": 1},
+		"a": {"c": 1, "b": 2},
+	}
+	if !reflect.DeepEqual(expected, seen) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, seen))
+	}
+
+	// Queries aren't resolved serially so siblings aren't available.
+	seen = map[string]map[string]interface{}{}
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ first: increment second: increment }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected = map[string]map[string]interface{}{
+		"first":  nil,
+		"second": nil,
+	}
+	if !reflect.DeepEqual(expected, seen) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, seen))
+	}
+}
+
+func TestMutations_FieldsReturningAChannelResolveToTheLastValue(t *testing.T) {
+	progress := func(values ...interface{}) <-chan interface{} {
+		ch := make(chan interface{}, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"status": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"import": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return progress(10, 50, 100), nil
+					},
+				},
+				"failedImport": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return progress(10, errors.New("import failed")), nil
+					},
+				},
+				"importSteps": &graphql.Field{
+					Type: graphql.NewList(graphql.Int),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return progress(10, 50, 100), nil
+					},
+				},
+				"skippedImport": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						var ch <-chan interface{}
+						return ch, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { import failedImport importSteps skippedImport }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"import":        100,
+			"failedImport":  nil,
+			"importSteps":   []interface{}{10, 50, 100},
+			"skippedImport": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "import failed",
+				Type:      gqlerrors.ErrorTypeInternal,
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"failedImport"},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -1,6 +1,7 @@
 package graphql_test
 
 import (
+	"errors"
 	"reflect"
 	"sort"
 	"testing"
@@ -129,6 +130,7 @@ func TestNonNull_NullsANullableFieldThatThrowsSynchronously(t *testing.T) {
 						Line: 3, Column: 9,
 					},
 				},
+				Path: []interface{}{"sync"},
 			},
 		},
 	}
@@ -169,6 +171,7 @@ func TestNonNull_NullsANullableFieldThatThrowsInAPromise(t *testing.T) {
 						Line: 3, Column: 9,
 					},
 				},
+				Path: []interface{}{"promise"},
 			},
 		},
 	}
@@ -211,6 +214,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANullableFieldThat
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"nest", "nonNullSync"},
 			},
 		},
 	}
@@ -253,6 +257,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"nest", "nonNullPromise"},
 			},
 		},
 	}
@@ -295,6 +300,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"promiseNest", "nonNullSync"},
 			},
 		},
 	}
@@ -337,6 +343,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"promiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -422,6 +429,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "sync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -429,6 +437,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 7, Column: 13},
 				},
+				Path: []interface{}{"nest", "nest", "sync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -436,6 +445,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 11, Column: 13},
 				},
+				Path: []interface{}{"nest", "promiseNest", "sync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -443,6 +453,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 16, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "sync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -450,6 +461,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 19, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "nest", "sync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -457,6 +469,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 23, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "promiseNest", "sync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -464,6 +477,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 5, Column: 11},
 				},
+				Path: []interface{}{"nest", "promise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -471,6 +485,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 8, Column: 13},
 				},
+				Path: []interface{}{"nest", "nest", "promise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -478,6 +493,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 12, Column: 13},
 				},
+				Path: []interface{}{"nest", "promiseNest", "promise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -485,6 +501,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 17, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "promise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -492,6 +509,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 20, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "nest", "promise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -499,6 +517,7 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 24, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "promiseNest", "promise"},
 			},
 		},
 	}
@@ -590,6 +609,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldThrowsInALongChainOfField
 				Locations: []location.SourceLocation{
 					{Line: 8, Column: 19},
 				},
+				Path: []interface{}{"nest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -597,6 +617,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldThrowsInALongChainOfField
 				Locations: []location.SourceLocation{
 					{Line: 19, Column: 19},
 				},
+				Path: []interface{}{"promiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -604,6 +625,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldThrowsInALongChainOfField
 				Locations: []location.SourceLocation{
 					{Line: 30, Column: 19},
 				},
+				Path: []interface{}{"anotherNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -611,6 +633,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldThrowsInALongChainOfField
 				Locations: []location.SourceLocation{
 					{Line: 41, Column: 19},
 				},
+				Path: []interface{}{"anotherPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -721,6 +744,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "nonNullSync"},
 			},
 		},
 	}
@@ -761,6 +785,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "nonNullPromise"},
 			},
 		},
 	}
@@ -802,6 +827,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "nonNullSync"},
 			},
 		},
 	}
@@ -842,6 +868,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 				Locations: []location.SourceLocation{
 					{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -1003,6 +1030,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 				Locations: []location.SourceLocation{
 					{Line: 8, Column: 19},
 				},
+				Path: []interface{}{"nest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -1010,6 +1038,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 				Locations: []location.SourceLocation{
 					{Line: 19, Column: 19},
 				},
+				Path: []interface{}{"promiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -1017,6 +1046,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 				Locations: []location.SourceLocation{
 					{Line: 30, Column: 19},
 				},
+				Path: []interface{}{"anotherNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 			{
 				Type:    gqlerrors.ErrorTypeInternal,
@@ -1024,6 +1054,7 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 				Locations: []location.SourceLocation{
 					{Line: 41, Column: 19},
 				},
+				Path: []interface{}{"anotherPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -1066,6 +1097,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldThrows(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullSync"},
 			},
 		},
 	}
@@ -1100,6 +1132,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldErrors(t *testing.T) {
 				Locations: []location.SourceLocation{
 					{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullPromise"},
 			},
 		},
 	}
@@ -1134,6 +1167,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldReturnsNull(t *testing.T)
 				Locations: []location.SourceLocation{
 					{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullSync"},
 			},
 		},
 	}
@@ -1168,6 +1202,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldResolvesNull(t *testing.T
 				Locations: []location.SourceLocation{
 					{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullPromise"},
 			},
 		},
 	}
@@ -1189,3 +1224,119 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldResolvesNull(t *testing.T
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func nonNullListTestSchema(t *testing.T) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := p.Source.(string)
+					if name == "" {
+						return nil, errors.New("no name")
+					}
+					return name, nil
+				},
+			},
+		},
+	})
+	teamType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Team",
+		Fields: graphql.Fields{
+			"members": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+	teamType.AddFieldConfig("parent", &graphql.Field{
+		Type: graphql.NewNonNull(teamType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"team": &graphql.Field{
+					Type: teamType,
+					Args: graphql.FieldConfigArgument{
+						"members": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						var members []string
+						for _, m := range p.Args["members"].([]interface{}) {
+							members = append(members, m.(string))
+						}
+						return members, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	return schema
+}
+
+func TestNonNull_NullsTheNearestNullableParentOfANonNullListOfNonNullItems(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        nonNullListTestSchema(t),
+		RequestString: `{ team(members: ["a", "", "c", ""]) { members { name } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"team": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Type:      gqlerrors.ErrorTypeInternal,
+				Message:   "no name",
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"team", "members", 1, "name"},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestNonNull_NullsTheNearestNullableParentThroughDeeplyNestedNonNullFields(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        nonNullListTestSchema(t),
+		RequestString: `{ team(members: [""]) { parent { parent { members { name } } } } ok: team(members: ["a"]) { members { name } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"team": nil,
+			"ok": map[string]interface{}{
+				"members": []interface{}{
+					map[string]interface{}{"name": "a"},
+				},
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Type:      gqlerrors.ErrorTypeInternal,
+				Message:   "no name",
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"team", "parent", "parent", "members", 0, "name"},
+			},
+		},
+	}
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
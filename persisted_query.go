@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/location"
+)
+
+// PersistedQueryStore stores the queries of automatic persisted queries by the
+// hex encoded SHA-256 hash of their text. Implementations must be safe for
+// concurrent use.
+type PersistedQueryStore interface {
+	Get(hash string) (query string, ok bool)
+	Set(hash string, query string)
+}
+
+// ErrorCodePersistedQueryNotFound is the extensions code of the error returned
+// for a persisted query hash that isn't in the store, which prompts clients to
+// send the query again along with its hash.
+const ErrorCodePersistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+
+// persistedQuery returns the query for the request and, if it should be stored
+// once it's known to be valid, its hash. If the hash is unknown or doesn't match
+// the query it returns the result to respond with instead.
+func persistedQuery(p *Params) (query string, registerHash string, result *Result) {
+	if p.PersistedQueryStore == nil || p.PersistedQueryHash == "" {
+		return p.RequestString, "", nil
+	}
+	hash := strings.ToLower(p.PersistedQueryHash)
+	if p.RequestString == "" {
+		query, ok := p.PersistedQueryStore.Get(hash)
+		if !ok {
+			return "", "", &Result{Errors: []gqlerrors.FormattedError{{
+				Message:    "PersistedQueryNotFound",
+				Type:       gqlerrors.ErrorTypeBadQuery,
+				Locations:  []location.SourceLocation{},
+				Extensions: map[string]interface{}{"code": ErrorCodePersistedQueryNotFound},
+			}}}
+		}
+		return query, "", nil
+	}
+	sum := sha256.Sum256([]byte(p.RequestString))
+	if hex.EncodeToString(sum[:]) != hash {
+		return "", "", &Result{Errors: []gqlerrors.FormattedError{{
+			Message:   "provided sha does not match query",
+			Type:      gqlerrors.ErrorTypeBadQuery,
+			Locations: []location.SourceLocation{},
+		}}}
+	}
+	return p.RequestString, hash, nil
+}
@@ -1,6 +1,7 @@
 package graphql
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -27,7 +28,8 @@ var SpecifiedRules = []ValidationRuleFn{
 	NoUndefinedVariablesRule,
 	NoUnusedFragmentsRule,
 	NoUnusedVariablesRule,
-	// OverlappingFieldsCanBeMergedRule, TODO(@samuel): disabled for now as it has a very large performance impact
+	OneOfInputObjectsRule,
+	OverlappingFieldsCanBeMergedRule,
 	PossibleFragmentSpreadsRule,
 	ProvidedNonNullArgumentsRule,
 	ScalarLeafsRule,
@@ -41,6 +43,9 @@ var SpecifiedRules = []ValidationRuleFn{
 }
 
 type ValidationRuleInstance struct {
+	// Name is the machine-readable name of the rule reported with its errors
+	// in ValidationErrorDetail (e.g. "FieldsOnCorrectType").
+	Name  string
 	Enter visitor.VisitFunc
 	Leave visitor.VisitFunc
 }
@@ -70,6 +75,7 @@ func reportErrorAndReturn(context *ValidationContext, message string, nodes []as
 // of the type expected by their position.
 func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "ArgumentsOfCorrectType",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			if argAST, ok := p.Node.(*ast.Argument); ok {
 				value := argAST.Value
@@ -89,7 +95,7 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 							context,
 							fmt.Sprintf(`Argument "%v" has invalid value %v.%v`,
 								argNameValue, printer.Print(value), messagesStr),
-							[]ast.Node{value},
+							invalidValueNodes(argDef.Type, value),
 						)
 					}
 				}
@@ -106,6 +112,7 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 // type expected by their definition.
 func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "DefaultValuesOfCorrectType",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.VariableDefinition:
@@ -135,7 +142,7 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 							context,
 							fmt.Sprintf(`Variable "$%v" has invalid default value: %v.%v`,
 								name, printer.Print(defaultValue), messagesStr),
-							[]ast.Node{defaultValue},
+							invalidValueNodes(ttype, defaultValue),
 						)
 					}
 				}
@@ -192,6 +199,7 @@ func UndefinedFieldMessage(fieldName string, ttypeName string, suggestedTypeName
 // parent type, or are an allowed meta field such as __typenamme
 func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "FieldsOnCorrectType",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			var action = visitor.ActionNoChange
 			if node, ok := p.Node.(*ast.Field); ok {
@@ -239,6 +247,9 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 	suggestedObjectMap := make(map[string]bool)
 
 	for _, possibleType := range possibleTypes {
+		if !schema.typeVisible(possibleType.Name()) || !schema.fieldVisible(possibleType.Name(), fieldName) {
+			continue
+		}
 		if field, ok := possibleType.Fields()[fieldName]; !ok || field == nil {
 			continue
 		}
@@ -247,6 +258,9 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 		suggestedObjectMap[possibleType.Name()] = true
 
 		for _, possibleInterface := range possibleType.Interfaces() {
+			if !schema.typeVisible(possibleInterface.Name()) || !schema.fieldVisible(possibleInterface.Name(), fieldName) {
+				continue
+			}
 			if field, ok := possibleInterface.Fields()[fieldName]; !ok || field == nil {
 				continue
 			}
@@ -307,7 +321,9 @@ func getSuggestedFieldNames(schema *Schema, ttype Output, fieldName string) []st
 
 	possibleFieldNames := []string{}
 	for possibleFieldName := range fields {
-		possibleFieldNames = append(possibleFieldNames, possibleFieldName)
+		if schema.fieldVisible(ttype.Name(), possibleFieldName) {
+			possibleFieldNames = append(possibleFieldNames, possibleFieldName)
+		}
 	}
 	return suggestionList(fieldName, possibleFieldNames)
 }
@@ -339,6 +355,7 @@ func (s suggestedInterfaceSortedSlice) Less(i, j int) bool {
 // type condition must also be a composite type.
 func FragmentsOnCompositeTypesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "FragmentsOnCompositeTypes",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.InlineFragment:
@@ -379,6 +396,21 @@ func unknownArgMessage(argName string, fieldName string, parentTypeName string,
 	return message
 }
 
+func unknownInputFieldMessage(fieldName string, ttype *InputObject) string {
+	fields := ttype.Fields()
+	fieldNames := make([]string, 0, len(fields))
+	for name := range fields {
+		fieldNames = append(fieldNames, name)
+	}
+	message := fmt.Sprintf(`In field "%v": Unknown field on input type "%v".`, fieldName, ttype.Name())
+
+	if suggested := suggestionList(fieldName, fieldNames); len(suggested) > 0 {
+		message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggested))
+	}
+
+	return message
+}
+
 func unknownDirectiveArgMessage(argName string, directiveName string, suggestedArgs []string) string {
 	message := fmt.Sprintf(`Unknown argument "%v" on directive "@%v".`, argName, directiveName)
 
@@ -395,6 +427,7 @@ func unknownDirectiveArgMessage(argName string, directiveName string, suggestedA
 // that field.
 func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "KnownArgumentNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			var action = visitor.ActionNoChange
 			if node, ok := p.Node.(*ast.Argument); ok {
@@ -477,6 +510,7 @@ func MisplaceDirectiveMessage(directiveName, location string) string {
 // schema and legally positioned.
 func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "KnownDirectives",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			if node, ok := p.Node.(*ast.Directive); ok {
 				nodeName := ""
@@ -586,6 +620,7 @@ func getDirectiveLocationForASTPath(ancestors []ast.Node) string {
 // to fragments defined in the same document.
 func KnownFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "KnownFragmentNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			var action = visitor.ActionNoChange
 			if node, ok := p.Node.(*ast.FragmentSpread); ok {
@@ -623,6 +658,7 @@ func unknownTypeMessage(typeName string, suggestedTypes []string) string {
 // variable definitions and fragment conditions) are defined by the type schema.
 func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "KnownTypeNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.ObjectDefinition:
@@ -639,12 +675,21 @@ func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
 				if typeName != nil {
 					typeNameValue = typeName.Value
 				}
-				ttype := context.Schema().Type(typeNameValue)
-				if ttype == nil {
+				ttype, err := context.Schema().LoadType(typeNameValue)
+				if err != nil {
+					return reportErrorAndReturn(
+						context,
+						fmt.Sprintf(`Type "%v" could not be loaded: %v`, typeNameValue, err),
+						[]ast.Node{node},
+					)
+				}
+				if ttype == nil || !context.Schema().typeVisible(typeNameValue) {
 					typeMap := context.Schema().TypeMap()
 					suggestedTypes := make([]string, 0, len(typeMap))
 					for key := range typeMap {
-						suggestedTypes = append(suggestedTypes, key)
+						if context.Schema().typeVisible(key) {
+							suggestedTypes = append(suggestedTypes, key)
+						}
 					}
 					return reportErrorAndReturn(
 						context,
@@ -665,6 +710,7 @@ func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
 func LoneAnonymousOperationRule(context *ValidationContext) *ValidationRuleInstance {
 	var operationCount = 0
 	return &ValidationRuleInstance{
+		Name: "LoneAnonymousOperation",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.Document:
@@ -688,6 +734,286 @@ func LoneAnonymousOperationRule(context *ValidationContext) *ValidationRuleInsta
 	}
 }
 
+// MaxComplexityRule Max complexity
+//
+// A GraphQL operation is only valid if its complexity doesn't exceed max.
+// Each field costs 1 plus the complexity of its selection set unless the
+// field defines a Complexity function. Since variables aren't known during
+// validation, an operation that passes a variable to a field with a Complexity
+// function has unbounded complexity and is rejected. Use
+// MaxComplexityRuleWithVariables to validate such operations.
+func MaxComplexityRule(max int) ValidationRuleFn {
+	return maxComplexityRule(max, nil, false)
+}
+
+// MaxComplexityRuleWithVariables is like MaxComplexityRule, but arguments
+// given as variables are coerced using the variable values of the request.
+// Its validation results depend on the variables, so they mustn't be cached
+// by request string alone.
+func MaxComplexityRuleWithVariables(max int, variableValues map[string]interface{}) ValidationRuleFn {
+	return maxComplexityRule(max, variableValues, true)
+}
+
+func maxComplexityRule(max int, variableValues map[string]interface{}, variablesKnown bool) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		return &ValidationRuleInstance{
+			Name: "MaxComplexity",
+			Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+				if node, ok := p.Node.(*ast.OperationDefinition); ok {
+					rootType, err := getOperationRootType(*context.Schema(), node)
+					if err != nil || rootType == nil {
+						return visitor.ActionSkip, nil
+					}
+					c := &complexityCalculator{
+						context:           context,
+						variablesKnown:    variablesKnown,
+						fragmentCosts:     make(map[string]int),
+						visitingFragments: make(map[string]struct{}),
+					}
+					if variablesKnown {
+						// Invalid variables fail the request before it's
+						// executed, so there's no complexity to check.
+						if c.variables, err = getVariableValues(nil, *context.Schema(), node.VariableDefinitions, variableValues, false); err != nil {
+							return visitor.ActionSkip, nil
+						}
+					}
+					complexity := c.selectionSetComplexity(rootType, node.SelectionSet)
+					operation := "Operation"
+					if node.Name != nil && node.Name.Value != "" {
+						operation = fmt.Sprintf(`Operation "%s"`, node.Name.Value)
+					}
+					if c.unboundedBy != "" {
+						context.ReportError(newValidationError(
+							fmt.Sprintf(`%s has complexity that depends on variable "$%s", which must be known to check the maximum of %d.`, operation, c.unboundedBy, max),
+							[]ast.Node{node}))
+					} else if complexity > max {
+						context.ReportError(newValidationError(
+							fmt.Sprintf(`%s has complexity %d, which exceeds the maximum of %d.`, operation, complexity, max),
+							[]ast.Node{node}))
+					}
+					return visitor.ActionSkip, nil
+				}
+				return visitor.ActionNoChange, nil
+			},
+		}
+	}
+}
+
+// complexityCalculator computes the complexity of the selection sets of an
+// operation.
+type complexityCalculator struct {
+	context        *ValidationContext
+	variables      map[string]interface{}
+	variablesKnown bool
+	// fragmentCosts is the complexity of each fragment spread so far. It only
+	// depends on the fragment, as its selections apply to its type
+	// condition, so a fragment spread many times is only computed once.
+	fragmentCosts     map[string]int
+	visitingFragments map[string]struct{}
+	// unboundedBy is the name of a variable that the complexity depends on
+	// when the variables aren't known.
+	unboundedBy string
+}
+
+func (c *complexityCalculator) selectionSetComplexity(parentType Type, selectionSet *ast.SelectionSet) int {
+	if selectionSet == nil {
+		return 0
+	}
+	complexity := 0
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fieldDef := DefaultTypeInfoFieldDef(c.context.Schema(), parentType, selection)
+			if fieldDef == nil {
+				complexity = addComplexity(complexity, 1)
+				continue
+			}
+			fieldType, _ := GetNamed(fieldDef.Type).(Type)
+			childComplexity := c.selectionSetComplexity(fieldType, selection.SelectionSet)
+			if fieldDef.Complexity != nil {
+				if !c.variablesKnown {
+					for _, arg := range selection.Arguments {
+						if name := variableIn(arg.Value); name != "" && c.unboundedBy == "" {
+							c.unboundedBy = name
+						}
+					}
+				}
+				// Errors are ignored since invalid arguments are reported by
+				// other rules and again when the field is executed. Complexity
+				// gets nil args in that case.
+				args, _ := getArgumentValues(context.Background(), fieldDef.Args, selection.Arguments, c.variables, nil)
+				complexity = addComplexity(complexity, fieldDef.Complexity(childComplexity, args))
+			} else {
+				complexity = addComplexity(complexity, addComplexity(1, childComplexity))
+			}
+		case *ast.InlineFragment:
+			fragmentType := parentType
+			if selection.TypeCondition != nil {
+				fragmentType, _ = typeFromAST(*c.context.Schema(), selection.TypeCondition)
+			}
+			complexity = addComplexity(complexity, c.selectionSetComplexity(fragmentType, selection.SelectionSet))
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			name := selection.Name.Value
+			if cost, ok := c.fragmentCosts[name]; ok {
+				complexity = addComplexity(complexity, cost)
+				continue
+			}
+			fragment := c.context.Fragment(name)
+			if _, ok := c.visitingFragments[name]; ok || fragment == nil {
+				continue
+			}
+			fragmentType, _ := typeFromAST(*c.context.Schema(), fragment.TypeCondition)
+			c.visitingFragments[name] = struct{}{}
+			cost := c.selectionSetComplexity(fragmentType, fragment.SelectionSet)
+			delete(c.visitingFragments, name)
+			c.fragmentCosts[name] = cost
+			complexity = addComplexity(complexity, cost)
+		}
+	}
+	return complexity
+}
+
+// addComplexity adds complexities, saturating rather than overflowing as
+// fragments spread many times can make the complexity grow exponentially.
+func addComplexity(a, b int) int {
+	if b > 0 && a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// variableIn returns the name of a variable used in the value, or "" if it
+// doesn't use any.
+func variableIn(value ast.Value) string {
+	switch value := value.(type) {
+	case *ast.Variable:
+		if value.Name != nil {
+			return value.Name.Value
+		}
+	case *ast.ListValue:
+		for _, v := range value.Values {
+			if name := variableIn(v); name != "" {
+				return name
+			}
+		}
+	case *ast.ObjectValue:
+		for _, f := range value.Fields {
+			if name := variableIn(f.Value); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// NewMaxFieldCountRule Max field count
+//
+// A GraphQL operation is only valid if it selects at most max fields once its
+// fragments are expanded. Fields selected more than once under the same
+// response key in a selection set are merged like they are during execution,
+// so they're only counted once along with their merged selections.
+func NewMaxFieldCountRule(max int) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		return &ValidationRuleInstance{
+			Name: "MaxFieldCount",
+			Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+				if node, ok := p.Node.(*ast.OperationDefinition); ok {
+					selections := []fieldCountSelections{{selectionSet: node.SelectionSet}}
+					if count := selectedFieldCount(context, selections, max); count > max {
+						operation := "Operation"
+						if node.Name != nil && node.Name.Value != "" {
+							operation = fmt.Sprintf(`Operation "%s"`, node.Name.Value)
+						}
+						context.ReportError(newValidationError(
+							fmt.Sprintf(`%s selects more than the maximum of %d fields.`, operation, max),
+							[]ast.Node{node}))
+					}
+					return visitor.ActionSkip, nil
+				}
+				return visitor.ActionNoChange, nil
+			},
+		}
+	}
+}
+
+// fieldCountSelections is a selection set along with the fragments that were
+// spread to reach it, which may not be spread within it again.
+type fieldCountSelections struct {
+	selectionSet *ast.SelectionSet
+	fragments    []string
+}
+
+// selectedFieldCount returns the number of fields selected by the merged
+// selection sets, giving up once more than limit fields have been counted as
+// fragments may be spread an exponential number of times.
+func selectedFieldCount(context *ValidationContext, selections []fieldCountSelections, limit int) int {
+	var keys []string
+	fields := make(map[string][]fieldCountSelections)
+	collected := make(map[string]bool)
+	for _, s := range selections {
+		collectFieldCountSelections(context, s.selectionSet, s.fragments, fields, &keys, collected)
+	}
+	count := 0
+	for _, key := range keys {
+		count++
+		if count > limit {
+			return count
+		}
+		count += selectedFieldCount(context, fields[key], limit-count)
+		if count > limit {
+			return count
+		}
+	}
+	return count
+}
+
+// collectFieldCountSelections adds the fields of the selection set to the
+// fields of the merged selection set. A fragment spread more than once in the
+// merged selection set selects the same fields each time, so it's only
+// collected once.
+func collectFieldCountSelections(context *ValidationContext, selectionSet *ast.SelectionSet, fragments []string, fields map[string][]fieldCountSelections, keys *[]string, collected map[string]bool) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			key := getFieldEntryKey(selection)
+			if _, ok := fields[key]; !ok {
+				*keys = append(*keys, key)
+				fields[key] = nil
+			}
+			if selection.SelectionSet != nil {
+				fields[key] = append(fields[key], fieldCountSelections{selectionSet: selection.SelectionSet, fragments: fragments})
+			}
+		case *ast.InlineFragment:
+			collectFieldCountSelections(context, selection.SelectionSet, fragments, fields, keys, collected)
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			name := selection.Name.Value
+			fragment := context.Fragment(name)
+			if fragment == nil || collected[name] {
+				continue
+			}
+			cyclic := false
+			for _, f := range fragments {
+				cyclic = cyclic || f == name
+			}
+			if cyclic {
+				continue
+			}
+			collected[name] = true
+			spread := append(append(make([]string, 0, len(fragments)+1), fragments...), name)
+			collectFieldCountSelections(context, fragment.SelectionSet, spread, fields, keys, collected)
+		}
+	}
+}
+
 type nodeSet struct {
 	set map[ast.Node]struct{}
 }
@@ -791,6 +1117,7 @@ func NoFragmentCyclesRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 
 	return &ValidationRuleInstance{
+		Name: "NoFragmentCycles",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.OperationDefinition:
@@ -824,6 +1151,7 @@ func UndefinedVarMessage(varName string, opName string) string {
 func NoUndefinedVariablesRule(context *ValidationContext) *ValidationRuleInstance {
 	var variableNameDefined = map[string]bool{}
 	return &ValidationRuleInstance{
+		Name: "NoUndefinedVariables",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.OperationDefinition:
@@ -877,6 +1205,7 @@ func NoUnusedFragmentsRule(context *ValidationContext) *ValidationRuleInstance {
 	var operationDefs []*ast.OperationDefinition
 
 	return &ValidationRuleInstance{
+		Name: "NoUnusedFragments",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.OperationDefinition:
@@ -936,6 +1265,7 @@ func UnusedVariableMessage(varName string, opName string) string {
 func NoUnusedVariablesRule(context *ValidationContext) *ValidationRuleInstance {
 	var variableDefs = []*ast.VariableDefinition{}
 	return &ValidationRuleInstance{
+		Name: "NoUnusedVariables",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch def := p.Node.(type) {
 			case *ast.OperationDefinition:
@@ -979,6 +1309,67 @@ func NoUnusedVariablesRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
+// OneOfInputObjectsRule OneOf input objects use non-nullable variables
+//
+// A GraphQL document is only valid if the variables given as the field of a
+// @oneOf input object are non-nullable, so that exactly one field is non-null.
+// Literal values of @oneOf input objects are checked by
+// ArgumentsOfCorrectTypeRule and DefaultValuesOfCorrectTypeRule.
+func OneOfInputObjectsRule(context *ValidationContext) *ValidationRuleInstance {
+	// The @oneOf input object each variable given as one of its fields
+	// belongs to. They're checked once the whole document was visited, since
+	// fragments may be defined after the operations using them.
+	oneOfVariables := make(map[*ast.Variable]*InputObject)
+	return &ValidationRuleInstance{
+		Name: "OneOfInputObjects",
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(*ast.ObjectValue); ok && len(node.Fields) == 1 {
+				if ttype, ok := GetNullable(context.InputType()).(*InputObject); ok && ttype.IsOneOf() {
+					if variable, ok := node.Fields[0].Value.(*ast.Variable); ok {
+						oneOfVariables[variable] = ttype
+					}
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			doc, ok := p.Node.(*ast.Document)
+			if !ok || len(oneOfVariables) == 0 {
+				return visitor.ActionNoChange, nil
+			}
+			for _, def := range doc.Definitions {
+				operation, ok := def.(*ast.OperationDefinition)
+				if !ok {
+					continue
+				}
+				varDefMap := make(map[string]*ast.VariableDefinition, len(operation.VariableDefinitions))
+				for _, varDef := range operation.VariableDefinitions {
+					if varDef.Variable != nil && varDef.Variable.Name != nil {
+						varDefMap[varDef.Variable.Name.Value] = varDef
+					}
+				}
+				for _, usage := range context.RecursiveVariableUsages(operation) {
+					ttype, ok := oneOfVariables[usage.Node]
+					if !ok || usage.Node.Name == nil {
+						continue
+					}
+					varDef := varDefMap[usage.Node.Name.Value]
+					if varDef == nil {
+						continue
+					}
+					if _, ok := varDef.Type.(*ast.NonNull); !ok {
+						context.ReportError(newValidationError(
+							fmt.Sprintf(`Variable "$%v" must be non-nullable to be used for OneOf Input Object "%v".`,
+								usage.Node.Name.Value, ttype.Name()),
+							[]ast.Node{varDef, usage.Node}))
+					}
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+}
+
 type fieldDefPair struct {
 	ParentType Composite
 	Field      *ast.Field
@@ -1128,8 +1519,8 @@ func sameArguments(args1, args2 []*ast.Argument) bool {
 			}
 			if arg1Name == arg2Name {
 				foundArgs2 = arg2
+				break
 			}
-			break
 		}
 		if foundArgs2 == nil {
 			return false
@@ -1265,9 +1656,9 @@ func findConflicts(context *ValidationContext, parentFieldsAreMutuallyExclusive
 	orderedName.Sort()
 
 	for _, responseName := range orderedName {
-		fields, _ := fieldMap[responseName]
-		for _, fieldA := range fields {
-			for _, fieldB := range fields {
+		fields := dedupeLeafFields(fieldMap[responseName])
+		for i, fieldA := range fields {
+			for _, fieldB := range fields[i+1:] {
 				c := findConflict(context, parentFieldsAreMutuallyExclusive, responseName, fieldA, fieldB, comparedSet)
 				if c != nil {
 					conflicts = append(conflicts, c)
@@ -1278,6 +1669,45 @@ func findConflicts(context *ValidationContext, parentFieldsAreMutuallyExclusive
 	return conflicts
 }
 
+// leafFieldKey identifies leaf fields that are trivially mergeable with each other.
+type leafFieldKey struct {
+	parentType Composite
+	fieldDef   *FieldDefinition
+	name       string
+	args       string
+}
+
+// dedupeLeafFields drops repeated selections of the same leaf field (same
+// parent type, name, and arguments) since they can't conflict with each other,
+// which avoids comparing every pair of them.
+func dedupeLeafFields(fields []*fieldDefPair) []*fieldDefPair {
+	if len(fields) < 3 {
+		return fields
+	}
+	seen := make(map[leafFieldKey]struct{}, len(fields))
+	deduped := make([]*fieldDefPair, 0, len(fields))
+	for _, field := range fields {
+		if field.Field.SelectionSet != nil || field.Field.Name == nil {
+			deduped = append(deduped, field)
+			continue
+		}
+		key := leafFieldKey{
+			parentType: field.ParentType,
+			fieldDef:   field.FieldDef,
+			name:       field.Field.Name.Value,
+		}
+		for _, arg := range field.Field.Arguments {
+			key.args += printer.Print(arg) + ","
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		deduped = append(deduped, field)
+	}
+	return deduped
+}
+
 // findConflict Determines if there is a conflict between two particular fields.
 func findConflict(context *ValidationContext, parentFieldsAreMutuallyExclusive bool, responseName string, field *fieldDefPair, field2 *fieldDefPair, comparedSet *pairSet) *conflict {
 
@@ -1415,6 +1845,7 @@ func OverlappingFieldsCanBeMergedRule(context *ValidationContext) *ValidationRul
 	}
 
 	return &ValidationRuleInstance{
+		Name: "OverlappingFieldsCanBeMerged",
 		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
 			if selectionSet, ok := p.Node.(*ast.SelectionSet); ok && selectionSet != nil {
 				parentType, _ := context.ParentType().(Named)
@@ -1505,13 +1936,16 @@ func doTypesOverlap(schema *Schema, t1 Type, t2 Type) bool {
 // and possible types which pass the type condition.
 func PossibleFragmentSpreadsRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "PossibleFragmentSpreads",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.InlineFragment:
+				// Fragments on non composite types are reported by
+				// FragmentsOnCompositeTypesRule.
 				fragType := context.Type()
 				parentType, _ := context.ParentType().(Type)
 
-				if fragType != nil && parentType != nil && !doTypesOverlap(context.Schema(), fragType, parentType) {
+				if fragType != nil && IsCompositeType(fragType) && parentType != nil && !doTypesOverlap(context.Schema(), fragType, parentType) {
 					return reportErrorAndReturn(
 						context,
 						fmt.Sprintf(`Fragment cannot be spread here as objects of `+
@@ -1526,7 +1960,7 @@ func PossibleFragmentSpreadsRule(context *ValidationContext) *ValidationRuleInst
 				}
 				fragType := getFragmentType(context, fragName)
 				parentType, _ := context.ParentType().(Type)
-				if fragType != nil && parentType != nil && !doTypesOverlap(context.Schema(), fragType, parentType) {
+				if fragType != nil && IsCompositeType(fragType) && parentType != nil && !doTypesOverlap(context.Schema(), fragType, parentType) {
 					return reportErrorAndReturn(
 						context,
 						fmt.Sprintf(`Fragment "%v" cannot be spread here as objects of `+
@@ -1546,6 +1980,7 @@ func PossibleFragmentSpreadsRule(context *ValidationContext) *ValidationRuleInst
 // have been provided.
 func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "ProvidedNonNullArguments",
 		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
 			// Validate on leave to allow for deeper errors to appear first.
 			if fieldAST, ok := p.Node.(*ast.Field); ok && fieldAST != nil {
@@ -1628,6 +2063,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 // sub selections) are of scalar or enum types.
 func ScalarLeafsRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "ScalarLeafs",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			if node, ok := p.Node.(*ast.Field); ok && node != nil {
 				nodeName := ""
@@ -1666,6 +2102,7 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 	knownArgNames := make(map[string]*ast.Name)
 
 	return &ValidationRuleInstance{
+		Name: "UniqueArgumentNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.Field:
@@ -1702,6 +2139,7 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 func UniqueFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	knownFragmentNames := make(map[string]*ast.Name)
 	return &ValidationRuleInstance{
+		Name: "UniqueFragmentNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.OperationDefinition:
@@ -1734,6 +2172,7 @@ func UniqueInputFieldNamesRule(context *ValidationContext) *ValidationRuleInstan
 	knownNames := make(map[string]*ast.Name)
 
 	return &ValidationRuleInstance{
+		Name: "UniqueInputFieldNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.ObjectValue:
@@ -1773,13 +2212,15 @@ func UniqueInputFieldNamesRule(context *ValidationContext) *ValidationRuleInstan
 func UniqueOperationNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	knownOperationNames := make(map[string]*ast.Name)
 	return &ValidationRuleInstance{
+		Name: "UniqueOperationNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.OperationDefinition:
-				var operationName string
-				if node.Name != nil {
-					operationName = node.Name.Value
+				// Anonymous operations are covered by LoneAnonymousOperationRule
+				if node.Name == nil {
+					return visitor.ActionSkip, nil
 				}
+				operationName := node.Name.Value
 				if nameAST, ok := knownOperationNames[operationName]; ok {
 					context.ReportError(newValidationError(
 						fmt.Sprintf(`There can only be one operation named "%v".`, operationName),
@@ -1801,6 +2242,7 @@ func UniqueOperationNamesRule(context *ValidationContext) *ValidationRuleInstanc
 // A GraphQL operation is only valid if all its variables are uniquely named.
 func UniqueVariableNamesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "UniqueVariableNames",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			if node, ok := p.Node.(*ast.OperationDefinition); ok {
 				knownVariableNames := make(map[string]*ast.Name)
@@ -1831,6 +2273,7 @@ func UniqueVariableNamesRule(context *ValidationContext) *ValidationRuleInstance
 // input types (scalar, enum, or input object).
 func VariablesAreInputTypesRule(context *ValidationContext) *ValidationRuleInstance {
 	return &ValidationRuleInstance{
+		Name: "VariablesAreInputTypes",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			if node, ok := p.Node.(*ast.VariableDefinition); ok && node != nil {
 				ttype, _ := typeFromAST(*context.Schema(), node.Type)
@@ -1869,6 +2312,7 @@ func effectiveType(varType Type, varDef *ast.VariableDefinition) Type {
 func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleInstance {
 	varDefMap := make(map[string]*ast.VariableDefinition)
 	return &ValidationRuleInstance{
+		Name: "VariablesInAllowedPosition",
 		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
 			switch node := p.Node.(type) {
 			case *ast.OperationDefinition:
@@ -1920,6 +2364,11 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 // Note that this only validates literal values, variables are assumed to
 // provide values of the correct type.
 func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+	// A null literal is treated the same as a missing value.
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		valueAST = nil
+	}
+
 	// A value must be provided if the type is non-null.
 	if ttype, ok := ttype.(*NonNull); ok {
 		if valueAST == nil {
@@ -1982,11 +2431,14 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			// check if field is defined
 			field, ok := fields[fieldASTName]
 			if !ok || field == nil {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldASTName))
+				messagesReduce = append(messagesReduce, unknownInputFieldMessage(fieldASTName, ttype))
 			}
 		}
 		for fieldName, field := range fields {
 			fieldAST := fieldASTMap[fieldName]
+			if fieldAST == nil && field.DefaultValue != nil {
+				continue
+			}
 			var fieldASTValue ast.Value
 			if fieldAST != nil {
 				fieldASTValue = fieldAST.Value
@@ -1997,6 +2449,9 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 				}
 			}
 		}
+		if message := oneOfLiteralMessage(ttype, valueAST); message != "" {
+			messagesReduce = append(messagesReduce, message)
+		}
 		return len(messagesReduce) == 0, messagesReduce
 	}
 
@@ -2014,84 +2469,222 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 	return true, nil
 }
 
-// Internal struct to sort results from suggestionList()
-type suggestionListResult struct {
-	Options   []string
-	Distances []float64
+// oneOfLiteralMessage returns why an object literal isn't a valid value of
+// the input object if it's a @oneOf input object, or "" if it is valid.
+func oneOfLiteralMessage(ttype *InputObject, valueAST *ast.ObjectValue) string {
+	if !ttype.IsOneOf() {
+		return ""
+	}
+	if len(valueAST.Fields) != 1 {
+		return fmt.Sprintf(`OneOf Input Object "%v" must specify exactly one key.`, ttype.Name())
+	}
+	if field := valueAST.Fields[0]; field.Name != nil {
+		if _, ok := field.Value.(*ast.NullValue); ok {
+			return fmt.Sprintf(`Field "%v.%v" must be non-null.`, ttype.Name(), field.Name.Value)
+		}
+	}
+	return ""
 }
 
-func (s suggestionListResult) Len() int {
-	return len(s.Options)
-}
-func (s suggestionListResult) Swap(i, j int) {
-	s.Options[i], s.Options[j] = s.Options[j], s.Options[i]
+// rejectedLiterals returns the scalar and enum literals within valueAST that
+// their type can't parse.
+func rejectedLiterals(ttype Input, valueAST ast.Value) []ast.Node {
+	if valueAST == nil {
+		return nil
+	}
+	if _, ok := valueAST.(*ast.Variable); ok {
+		return nil
+	}
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		if _, ok := ttype.(*NonNull); ok {
+			return []ast.Node{valueAST}
+		}
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		ofType, _ := ttype.OfType.(Input)
+		return rejectedLiterals(ofType, valueAST)
+	case *List:
+		itemType, _ := ttype.OfType.(Input)
+		listAST, ok := valueAST.(*ast.ListValue)
+		if !ok {
+			return rejectedLiterals(itemType, valueAST)
+		}
+		var nodes []ast.Node
+		for _, itemAST := range listAST.Values {
+			nodes = append(nodes, rejectedLiterals(itemType, itemAST)...)
+		}
+		return nodes
+	case *InputObject:
+		objectAST, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			return nil
+		}
+		fields := ttype.Fields()
+		var nodes []ast.Node
+		for _, fieldAST := range objectAST.Fields {
+			if fieldAST.Name == nil {
+				continue
+			}
+			if field := fields[fieldAST.Name.Value]; field != nil {
+				nodes = append(nodes, rejectedLiterals(field.Type, fieldAST.Value)...)
+			} else {
+				nodes = append(nodes, fieldAST)
+			}
+		}
+		if len(nodes) == 0 && oneOfLiteralMessage(ttype, objectAST) != "" {
+			nodes = append(nodes, objectAST)
+		}
+		return nodes
+	case *Scalar:
+		if isNullish(ttype.ParseLiteral(valueAST)) {
+			return []ast.Node{valueAST}
+		}
+	case *Enum:
+		if isNullish(ttype.ParseLiteral(valueAST)) {
+			return []ast.Node{valueAST}
+		}
+	}
+	return nil
 }
-func (s suggestionListResult) Less(i, j int) bool {
-	return s.Distances[i] < s.Distances[j]
+
+// invalidValueNodes returns the nodes to locate an error about an invalid value
+// at: the literals within it that were rejected, or else the value itself.
+func invalidValueNodes(ttype Input, valueAST ast.Value) []ast.Node {
+	if nodes := rejectedLiterals(ttype, valueAST); len(nodes) != 0 {
+		return nodes
+	}
+	return []ast.Node{valueAST}
 }
 
 // suggestionList Given an invalid input string and a list of valid options, returns a filtered
-// list of valid options sorted based on their similarity with the input.
+// list of valid options sorted based on their similarity with the input. Like
+// graphql-js, options are suggested when they're within floor(0.4 * len(input)) + 1
+// edits of the input, and are ordered by distance and then naturally by name.
 func suggestionList(input string, options []string) []string {
-	dists := []float64{}
-	filteredOpts := []string{}
-	inputThreshold := float64(len(input) / 2)
-
+	threshold := int(math.Floor(float64(len(input))*0.4)) + 1
+	distances := make(map[string]int, len(options))
+	suggested := []string{}
 	for _, opt := range options {
-		dist := lexicalDistance(input, opt)
-		threshold := math.Max(inputThreshold, float64(len(opt)/2))
-		threshold = math.Max(threshold, 1)
-		if dist <= threshold {
-			filteredOpts = append(filteredOpts, opt)
-			dists = append(dists, dist)
+		if _, ok := distances[opt]; ok {
+			continue
+		}
+		if dist, ok := lexicalDistance(input, opt, threshold); ok {
+			distances[opt] = dist
+			suggested = append(suggested, opt)
 		}
 	}
-	//sort results
-	suggested := suggestionListResult{filteredOpts, dists}
-	sort.Sort(suggested)
-	return suggested.Options
+	sort.SliceStable(suggested, func(i, j int) bool {
+		a, b := suggested[i], suggested[j]
+		if distances[a] != distances[b] {
+			return distances[a] < distances[b]
+		}
+		return naturalLess(a, b)
+	})
+	return suggested
 }
 
 // lexicalDistance Computes the lexical distance between strings A and B.
 // The "distance" between two strings is given by counting the minimum number
 // of edits needed to transform string A into string B. An edit can be an
 // insertion, deletion, or substitution of a single character, or a swap of two
-// adjacent characters.
+// adjacent characters. Strings that only differ in case are a single edit
+// apart. It returns false as soon as the distance exceeds the threshold.
 // This distance can be useful for detecting typos in input or sorting
-func lexicalDistance(a, b string) float64 {
-	d := [][]float64{}
-	aLen := len(a)
-	bLen := len(b)
-	for i := 0; i <= aLen; i++ {
-		d = append(d, []float64{float64(i)})
+func lexicalDistance(input, option string, threshold int) (int, bool) {
+	if input == option {
+		return 0, true
+	}
+	a, b := []rune(strings.ToLower(option)), []rune(strings.ToLower(input))
+	if string(a) == string(b) {
+		return 1, true
 	}
-	for k := 1; k <= bLen; k++ {
-		d[0] = append(d[0], float64(k))
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a)-len(b) > threshold {
+		return 0, false
 	}
 
-	for i := 1; i <= aLen; i++ {
-		for k := 1; k <= bLen; k++ {
-			cost := 1.0
+	// Only the last three rows are needed to compute the next one.
+	var rows [3][]int
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+	}
+	for k := range rows[0] {
+		rows[0][k] = k
+	}
+	for i := 1; i <= len(a); i++ {
+		up, current := rows[(i-1)%3], rows[i%3]
+		current[0] = i
+		smallest := i
+		for k := 1; k <= len(b); k++ {
+			cost := 1
 			if a[i-1] == b[k-1] {
-				cost = 0.0
+				cost = 0
 			}
-			minCostFloat := math.Min(
-				d[i-1][k]+1.0,
-				d[i][k-1]+1.0,
-			)
-			minCostFloat = math.Min(
-				minCostFloat,
-				d[i-1][k-1]+cost,
-			)
-			d[i] = append(d[i], minCostFloat)
+			cell := up[k] + 1
+			if current[k-1]+1 < cell {
+				cell = current[k-1] + 1
+			}
+			if up[k-1]+cost < cell {
+				cell = up[k-1] + cost
+			}
+			if i > 1 && k > 1 && a[i-1] == b[k-2] && a[i-2] == b[k-1] {
+				if swapped := rows[(i-2)%3][k-2] + 1; swapped < cell {
+					cell = swapped
+				}
+			}
+			if cell < smallest {
+				smallest = cell
+			}
+			current[k] = cell
+		}
+		// Distances can't decrease in later rows.
+		if smallest > threshold {
+			return 0, false
+		}
+	}
+	dist := rows[len(a)%3][len(b)]
+	return dist, dist <= threshold
+}
 
-			if i > 1 && k < 1 &&
-				a[i-1] == b[k-2] &&
-				a[i-2] == b[k-1] {
-				d[i][k] = math.Min(d[i][k], d[i-2][k-2]+cost)
+// naturalLess compares strings like graphql-js's naturalCompare, ordering runs
+// of digits by their numeric value (e.g. "a2" before "a10").
+func naturalLess(a, b string) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			si, sj := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
 			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+			na := strings.TrimLeft(a[si:i], "0")
+			nb := strings.TrimLeft(b[sj:j], "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			if i-si != j-sj {
+				return i-si < j-sj
+			}
+			continue
+		}
+		if a[i] != b[j] {
+			return a[i] < b[j]
 		}
+		i++
+		j++
 	}
+	return len(a)-i < len(b)-j
+}
 
-	return d[aLen][bLen]
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
 }
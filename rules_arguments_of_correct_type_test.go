@@ -5,6 +5,7 @@ import (
 
 	"github.com/sprucehealth/graphql"
 	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
 	"github.com/sprucehealth/graphql/testutil"
 )
 
@@ -501,7 +502,7 @@ func TestValidate_ArgValuesOfCorrectType_InvalidListValue_IncorrectItemType(t *t
 		[]gqlerrors.FormattedError{
 			testutil.RuleError(
 				"Argument \"stringListArg\" has invalid value [\"one\", 2].\nIn element #1: Expected type \"String\", found 2.",
-				4, 47,
+				4, 55,
 			),
 		})
 }
@@ -647,6 +648,32 @@ func TestValidate_ArgValuesOfCorrectType_InvalidNonNullableValue_IncorrectValueA
 		})
 }
 
+func TestValidate_ArgValuesOfCorrectType_ValidValue_NullIntoNullableType(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            intArgField(intArg: null)
+            stringListArgField(stringListArg: ["one", null])
+          }
+        }
+    `)
+}
+func TestValidate_ArgValuesOfCorrectType_InvalidNonNullableValue_Null(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            multipleReqs(req1: 1, req2: null)
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				"Argument \"req2\" has invalid value null.\nExpected \"Int!\", found null.",
+				4, 41,
+			),
+		})
+}
+
 func TestValidate_ArgValuesOfCorrectType_ValidInputObjectValue_OptionalArg_DespiteRequiredFieldInType(t *testing.T) {
 	testutil.ExpectPassesRule(t, graphql.ArgumentsOfCorrectTypeRule, `
         {
@@ -743,7 +770,7 @@ func TestValidate_ArgValuesOfCorrectType_InvalidInputObjectValue_PartialObject_I
 		[]gqlerrors.FormattedError{
 			testutil.RuleError(
 				"Argument \"complexArg\" has invalid value {stringListField: [\"one\", 2], requiredField: true}.\nIn field \"stringListField\": In element #1: Expected type \"String\", found 2.",
-				4, 41,
+				5, 40,
 			),
 		})
 }
@@ -760,8 +787,23 @@ func TestValidate_ArgValuesOfCorrectType_InvalidInputObjectValue_PartialObject_U
         `,
 		[]gqlerrors.FormattedError{
 			testutil.RuleError(
-				"Argument \"complexArg\" has invalid value {requiredField: true, unknownField: \"value\"}.\nIn field \"unknownField\": Unknown field.",
-				4, 41,
+				"Argument \"complexArg\" has invalid value {requiredField: true, unknownField: \"value\"}.\nIn field \"unknownField\": Unknown field on input type \"ComplexInput\".",
+				6, 15,
+			),
+		})
+}
+func TestValidate_ArgValuesOfCorrectType_InvalidInputObjectValue_MisspelledFieldArg(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            complexArgField(complexArg: {requiredField: true, intFeld: 4})
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				"Argument \"complexArg\" has invalid value {requiredField: true, intFeld: 4}.\nIn field \"intFeld\": Unknown field on input type \"ComplexInput\". Did you mean \"intField\"?",
+				4, 63,
 			),
 		})
 }
@@ -799,3 +841,77 @@ func TestValidate_ArgValuesOfCorrectType_DirectiveArguments_WithDirectivesWithIn
 			),
 		})
 }
+func TestValidate_ArgValuesOfCorrectType_DirectiveArguments_WithNonBooleanSkipCondition(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          dog @skip(if: 5) {
+            name @skip(if: [true])
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				`Argument "if" has invalid value 5.`+
+					"\nExpected type \"Boolean\", found 5.",
+				3, 25,
+			),
+			testutil.RuleError(
+				`Argument "if" has invalid value [true].`+
+					"\nExpected type \"Boolean\", found [true].",
+				4, 28,
+			),
+		})
+}
+func TestValidate_ArgValuesOfCorrectType_CustomScalarRejectingLiteral_LocatedAtLiteral(t *testing.T) {
+	dateType := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "Date",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return value
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if v, ok := valueAST.(*ast.StringValue); ok && len(v.Value) == len("2006-01-02") {
+				return v.Value
+			}
+			return nil
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"after": &graphql.InputObjectFieldConfig{Type: dateType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"events": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: filterType},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.ExpectFailsRuleWithSchema(t, &schema, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          events(filter: {
+            after: "yesterday"
+          })
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				`Argument "filter" has invalid value {after: "yesterday"}.`+
+					"\nIn field \"after\": Expected type \"Date\", found \"yesterday\".",
+				4, 20,
+			),
+		})
+}
@@ -98,6 +98,6 @@ func TestValidate_VariableDefaultValuesOfCorrectType_ListVariablesWithInvalidIte
 			testutil.RuleError(
 				`Variable "$a" has invalid default value: ["one", 2].`+
 					"\nIn element #1: Expected type \"String\", found 2.",
-				2, 40),
+				2, 48),
 		})
 }
@@ -0,0 +1,126 @@
+package graphql_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+var complexityTestSchema = func() *graphql.Schema {
+	multiplyByFirst := func(childComplexity int, args map[string]interface{}) int {
+		first, _ := args["first"].(int)
+		return childComplexity * first
+	}
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType.AddFieldConfig("friends", &graphql.Field{
+		Type: graphql.NewList(userType),
+		Args: graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+		},
+		Complexity: multiplyByFirst,
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(userType),
+					Args: graphql.FieldConfigArgument{
+						"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					},
+					Complexity: multiplyByFirst,
+				},
+				"me": &graphql.Field{Type: userType},
+			},
+		}),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return &schema
+}()
+
+func TestValidate_MaxComplexity_WithinLimit(t *testing.T) {
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRule(15), `
+      query Q {
+        users(first: 5) {
+          name
+          friends(first: 2) { name }
+        }
+      }
+    `)
+}
+
+func TestValidate_MaxComplexity_ComplexityMultipliedByFirst(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRule(14), `
+      query Q {
+        users(first: 5) {
+          name
+          friends(first: 2) { name }
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation "Q" has complexity 15, which exceeds the maximum of 14.`, 2, 7),
+	})
+}
+
+func TestValidate_MaxComplexity_UsesArgumentDefaultsAndFragments(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRule(11), `
+      {
+        me { ...userFields }
+        users { ...userFields }
+      }
+      fragment userFields on User {
+        name
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation has complexity 12, which exceeds the maximum of 11.`, 2, 7),
+	})
+}
+
+func TestValidate_MaxComplexity_RejectsUnknownVariablesInComplexityArguments(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRule(100), `
+      query Q($n: Int) {
+        users(first: $n) { name }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation "Q" has complexity that depends on variable "$n", which must be known to check the maximum of 100.`, 2, 7),
+	})
+}
+
+func TestValidate_MaxComplexity_CoercesVariables(t *testing.T) {
+	query := `
+      query Q($n: Int = 3) {
+        users(first: $n) { name }
+      }
+    `
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRuleWithVariables(5, map[string]interface{}{"n": 5}), query)
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRuleWithVariables(3, nil), query)
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRuleWithVariables(5, map[string]interface{}{"n": 1000}), query, []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation "Q" has complexity 1000, which exceeds the maximum of 5.`, 2, 7),
+	})
+}
+
+func TestValidate_MaxComplexity_ComputesFragmentsSpreadManyTimesOnce(t *testing.T) {
+	// Each fragment spreads the next one twice, so expanding every spread
+	// would take 2^depth steps.
+	const depth = 30
+	var query strings.Builder
+	query.WriteString("{ me { ...F0 } }\n")
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&query, "fragment F%d on User { ...F%d ...F%d }\n", i, i+1, i+1)
+	}
+	fmt.Fprintf(&query, "fragment F%d on User { name }\n", depth)
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.MaxComplexityRule(100), query.String(), []gqlerrors.FormattedError{
+		testutil.RuleError(fmt.Sprintf(`Operation has complexity %d, which exceeds the maximum of 100.`, 1+1<<depth), 1, 1),
+	})
+}
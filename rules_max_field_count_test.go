@@ -0,0 +1,83 @@
+package graphql_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestValidate_MaxFieldCount_MergesFieldsWithTheSameResponseKey(t *testing.T) {
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(4), `
+      {
+        me { name ...userFields }
+        me { name }
+        friend: me { name }
+      }
+      fragment userFields on User {
+        name
+      }
+    `)
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(4), `
+      query Q {
+        me { name ...userFields }
+        friend: me { name }
+      }
+      fragment userFields on User {
+        alias: name
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation "Q" selects more than the maximum of 4 fields.`, 2, 7),
+	})
+}
+
+func TestValidate_MaxFieldCount_ExpandsReusedFragments(t *testing.T) {
+	query := `
+      {
+        me { ...friendsTwice }
+        users { ...friendsTwice }
+      }
+      fragment friendsTwice on User {
+        friends { ...nameAndFriends }
+        others: friends { ...nameAndFriends }
+      }
+      fragment nameAndFriends on User {
+        name
+        friends { name }
+      }
+    `
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(18), query)
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(17), query, []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation selects more than the maximum of 17 fields.`, 2, 7),
+	})
+}
+
+func TestValidate_MaxFieldCount_StopsAtFragmentCycles(t *testing.T) {
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(2), `
+      {
+        me { ...friendsOfFriends }
+      }
+      fragment friendsOfFriends on User {
+        friends { ...friendsOfFriends }
+      }
+    `)
+}
+
+func TestValidate_MaxFieldCount_CollectsFragmentsSpreadManyTimesOnce(t *testing.T) {
+	// Each fragment spreads the next one twice, so expanding every spread
+	// would take 2^depth steps.
+	const depth = 30
+	var query strings.Builder
+	query.WriteString("{ me { ...F0 } }\n")
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&query, "fragment F%d on User { ...F%d ...F%d }\n", i, i+1, i+1)
+	}
+	fmt.Fprintf(&query, "fragment F%d on User { name }\n", depth)
+	testutil.ExpectPassesRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(2), query.String())
+	testutil.ExpectFailsRuleWithSchema(t, complexityTestSchema, graphql.NewMaxFieldCountRule(1), query.String(), []gqlerrors.FormattedError{
+		testutil.RuleError(`Operation selects more than the maximum of 1 fields.`, 1, 1),
+	})
+}
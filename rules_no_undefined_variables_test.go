@@ -263,3 +263,35 @@ func TestValidate_NoUndefinedVariables_VaMultipleUndefinedVariablesProduceMultip
 		testutil.RuleError(`Variable "$b" is not defined by operation "Bar".`, 11, 26, 5, 7),
 	})
 }
+func TestValidate_NoUndefinedVariables_VariableUsedOnlyInsideSpreadFragmentDirective(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.NoUndefinedVariablesRule, `
+      query Foo($b: String) {
+        ...FragA
+      }
+      fragment FragA on Type {
+        ... on Type {
+          field(a: $b) @include(if: $a)
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$a" is not defined by operation "Foo".`, 7, 37, 2, 7),
+	})
+}
+func TestValidate_NoUndefinedVariables_VariablesInDirectiveArgumentsNotDefined(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.NoUndefinedVariablesRule, `
+      query Foo($a: Boolean) {
+        field @include(if: $a) @skip(if: $b)
+        ... @skip(if: $c) {
+          field
+        }
+        ...FragA @include(if: $d)
+      }
+      fragment FragA on Type {
+        field
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$b" is not defined by operation "Foo".`, 3, 42, 2, 7),
+		testutil.RuleError(`Variable "$c" is not defined by operation "Foo".`, 4, 23, 2, 7),
+		testutil.RuleError(`Variable "$d" is not defined by operation "Foo".`, 7, 31, 2, 7),
+	})
+}
@@ -187,3 +187,15 @@ func TestValidate_NoUnusedVariables_VariableNotUsedByFragmentUsedByOtherOperatio
 		testutil.RuleError(`Variable "$a" is never used in operation "Bar".`, 5, 17),
 	})
 }
+func TestValidate_NoUnusedVariables_VariableUsedOnlyInsideSpreadFragmentDirective(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.NoUnusedVariablesRule, `
+      query Foo($a: Boolean, $b: String) {
+        ...FragA
+      }
+      fragment FragA on Type {
+        ... on Type {
+          field(a: $b) @include(if: $a)
+        }
+      }
+    `)
+}
@@ -0,0 +1,91 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+var oneOfTestSchema = func() *graphql.Schema {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  user(by: UserBy): String
+  users(by: [UserBy!]): String
+}
+
+input UserBy @oneOf {
+  id: ID
+  email: String
+}
+`)
+	if err != nil {
+		panic(err)
+	}
+	return &schema
+}()
+
+func TestValidate_OneOfInputObjects_AcceptsExactlyOneNonNullField(t *testing.T) {
+	testutil.ExpectPassesRuleWithSchema(t, oneOfTestSchema, graphql.ArgumentsOfCorrectTypeRule, `
+      {
+        user(by: { id: "1" })
+        users(by: [{ id: "1" }, { email: "a@example.com" }])
+      }
+    `)
+}
+
+func TestValidate_OneOfInputObjects_RejectsNoFields(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, oneOfTestSchema, graphql.ArgumentsOfCorrectTypeRule, `
+      {
+        user(by: {})
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError("Argument \"by\" has invalid value {}.\n"+
+			`OneOf Input Object "UserBy" must specify exactly one key.`, 3, 18),
+	})
+}
+
+func TestValidate_OneOfInputObjects_RejectsManyFields(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, oneOfTestSchema, graphql.ArgumentsOfCorrectTypeRule, `
+      {
+        users(by: [{ id: "1", email: "a@example.com" }])
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError("Argument \"by\" has invalid value [{id: \"1\", email: \"a@example.com\"}].\n"+
+			`In element #1: OneOf Input Object "UserBy" must specify exactly one key.`, 3, 20),
+	})
+}
+
+func TestValidate_OneOfInputObjects_RejectsNullField(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, oneOfTestSchema, graphql.ArgumentsOfCorrectTypeRule, `
+      {
+        user(by: { id: null })
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError("Argument \"by\" has invalid value {id: null}.\n"+
+			`Field "UserBy.id" must be non-null.`, 3, 18),
+	})
+}
+
+func TestValidate_OneOfInputObjects_AcceptsNonNullableVariables(t *testing.T) {
+	testutil.ExpectPassesRuleWithSchema(t, oneOfTestSchema, graphql.OneOfInputObjectsRule, `
+      query Q($id: ID!, $by: UserBy!) {
+        user(by: { id: $id })
+        users(by: [$by])
+      }
+    `)
+}
+
+func TestValidate_OneOfInputObjects_RejectsNullableVariables(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, oneOfTestSchema, graphql.OneOfInputObjectsRule, `
+      query Q($id: ID) {
+        ...userFields
+      }
+      fragment userFields on Query {
+        user(by: { id: $id })
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$id" must be non-nullable to be used for OneOf Input Object "UserBy".`, 2, 15, 6, 24),
+	})
+}
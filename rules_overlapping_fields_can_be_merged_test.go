@@ -171,6 +171,43 @@ func TestValidate_OverlappingFieldsCanBeMerged_EncountersConflictInFragments(t *
 			7, 9, 10, 9),
 	})
 }
+func TestValidate_OverlappingFieldsCanBeMerged_SafelyMergesFieldsAcrossFragments(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.OverlappingFieldsCanBeMergedRule, `
+      {
+        complicatedArgs {
+          ...A
+          ...B
+          multipleReqs(req1: 1, req2: 2)
+        }
+      }
+      fragment A on ComplicatedArgs {
+        multipleReqs(req1: 1, req2: 2)
+      }
+      fragment B on ComplicatedArgs {
+        multipleReqs(req2: 2, req1: 1)
+      }
+    `)
+}
+func TestValidate_OverlappingFieldsCanBeMerged_ConflictingAliasesAcrossFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.OverlappingFieldsCanBeMergedRule, `
+      {
+        complicatedArgs {
+          ...A
+          ...B
+        }
+      }
+      fragment A on ComplicatedArgs {
+        opts: multipleOpts(opt1: 1, opt2: 2)
+      }
+      fragment B on ComplicatedArgs {
+        opts: multipleOpts(opt1: 1, opt2: 3)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fields "opts" conflict because they have differing arguments. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`,
+			9, 9, 12, 9),
+	})
+}
 func TestValidate_OverlappingFieldsCanBeMerged_ReportsEachConflictOnce(t *testing.T) {
 	testutil.ExpectFailsRule(t, graphql.OverlappingFieldsCanBeMergedRule, `
       {
@@ -225,6 +262,31 @@ func TestValidate_OverlappingFieldsCanBeMerged_DeepConflict(t *testing.T) {
 			7, 11),
 	})
 }
+func TestValidate_OverlappingFieldsCanBeMerged_DeepConflictInFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.OverlappingFieldsCanBeMergedRule, `
+      {
+        field {
+          ...A
+        }
+        field {
+          ...B
+        }
+      }
+      fragment A on Type {
+        x: a
+      }
+      fragment B on Type {
+        x: b
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fields "field" conflict because subfields "x" conflict because a and b are different fields. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`,
+			3, 9,
+			11, 9,
+			6, 9,
+			14, 9),
+	})
+}
 func TestValidate_OverlappingFieldsCanBeMerged_DeepConflictWithMultipleIssues(t *testing.T) {
 	testutil.ExpectFailsRule(t, graphql.OverlappingFieldsCanBeMergedRule, `
       {
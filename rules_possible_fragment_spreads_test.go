@@ -180,3 +180,20 @@ func TestValidate_PossibleFragmentSpreads_InterfaceIntoNonOverlappingUnion(t *te
 			`type "HumanOrAlien" can never be of type "Pet".`, 2, 62),
 	})
 }
+
+func TestValidate_PossibleFragmentSpreads_SiblingImplementationsInOperation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.PossibleFragmentSpreadsRule, `
+      {
+        pet {
+          ...dogFragment
+          ... on Cat {
+            ...dogFragment
+          }
+        }
+      }
+      fragment dogFragment on Dog { barkVolume }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment "dogFragment" cannot be spread here as objects of `+
+			`type "Cat" can never be of type "Dog".`, 6, 13),
+	})
+}
@@ -102,3 +102,28 @@ func TestValidate_UniqueOperationNames_MultipleOperationsOfSameNameOfDifferentTy
 		testutil.RuleError(`There can only be one operation named "Foo".`, 2, 13, 5, 20),
 	})
 }
+func TestValidate_UniqueOperationNames_MultipleAnonOperations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueOperationNamesRule, `
+      {
+        field
+      }
+      {
+        field
+      }
+    `)
+}
+func TestValidate_UniqueOperationNames_MultipleOperationsOfSameNameAmongOthers(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueOperationNamesRule, `
+      query Foo {
+        fieldA
+      }
+      query Bar {
+        fieldB
+      }
+      mutation Foo {
+        fieldC
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can only be one operation named "Foo".`, 2, 13, 8, 16),
+	})
+}
@@ -244,3 +244,30 @@ func TestValidate_VariablesInAllowedPosition_StringToNonNullableBooleanInDirecti
 			`expecting type "Boolean!".`, 2, 19, 3, 26),
 	})
 }
+
+func TestValidate_VariablesInAllowedPosition_IntToNonNullableBooleanInSkipDirective(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.VariablesInAllowedPositionRule, `
+      query Query($intVar: Int) {
+        dog @skip(if: $intVar)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$intVar" of type "Int" used in position `+
+			`expecting type "Boolean!".`, 2, 19, 3, 23),
+	})
+}
+
+func TestValidate_VariablesInAllowedPosition_StringToBooleanInDirectiveOnFragmentSpread(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.VariablesInAllowedPositionRule, `
+      query Query($stringVar: String!) {
+        ...frag @include(if: $stringVar)
+      }
+      fragment frag on QueryRoot {
+        dog {
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$stringVar" of type "String!" used in position `+
+			`expecting type "Boolean!".`, 2, 19, 3, 30),
+	})
+}
@@ -1,13 +1,41 @@
 package graphql
 
 import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sprucehealth/graphql/language/ast"
 )
 
+// SerializationError is the original error of the field error reported when a
+// resolver returns a value that a built-in scalar can't serialize.
+type SerializationError struct {
+	// Scalar is the name of the scalar.
+	Scalar string
+	// Value is the value returned by the resolver.
+	Value interface{}
+}
+
+func (e *SerializationError) Error() string {
+	return fmt.Sprintf("%s cannot represent a value of type %T.", e.Scalar, e.Value)
+}
+
+// newBuiltinScalar returns a scalar for which the executor reports values that
+// fail to serialize as field errors rather than returning null for them.
+func newBuiltinScalar(config ScalarConfig) *Scalar {
+	st := NewScalar(config)
+	st.serializeErrors = true
+	return st
+}
+
 func coerceInt(value interface{}) interface{} {
 	switch v := value.(type) {
 	case bool:
@@ -60,6 +88,12 @@ func coerceInt(value interface{}) interface{} {
 			return nil
 		}
 		return int(val)
+	case json.Number:
+		val, err := v.Int64()
+		if err != nil || val < int64(math.MinInt32) || val > int64(math.MaxInt32) {
+			return nil
+		}
+		return int(val)
 	}
 
 	// If the value cannot be transformed into an int, return nil instead of '0'
@@ -68,7 +102,7 @@ func coerceInt(value interface{}) interface{} {
 }
 
 // Int is the GraphQL Integer type definition.
-var Int = NewScalar(ScalarConfig{
+var Int = newBuiltinScalar(ScalarConfig{
 	Name: "Int",
 	Description: "The `Int` scalar type represents non-fractional signed whole numeric " +
 		"values. Int can represent values between -(2^31) and 2^31 - 1. ",
@@ -94,8 +128,18 @@ func coerceFloat64(value interface{}) interface{} {
 		return float64(0)
 	case int:
 		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
 	case int32:
 		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
 	case uint32:
 		return float64(v)
 	case int64:
@@ -112,12 +156,18 @@ func coerceFloat64(value interface{}) interface{} {
 			return nil
 		}
 		return val
+	case json.Number:
+		val, err := v.Float64()
+		if err != nil {
+			return nil
+		}
+		return val
 	}
-	return float64(0)
+	return nil
 }
 
 // Float is the GraphQL float type definition.
-var Float = NewScalar(ScalarConfig{
+var Float = newBuiltinScalar(ScalarConfig{
 	Name: "Float",
 	Description: "The `Float` scalar type represents signed double-precision fractional " +
 		"values as specified by " +
@@ -157,12 +207,49 @@ func coerceString(value interface{}) interface{} {
 		return strconv.FormatFloat(float64(v), 'f', -1, 32)
 	case float64:
 		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case encoding.TextMarshaler:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		return marshalText(v)
+	}
+	// Other basic values are printed, but composite values such as structs and
+	// maps don't have a meaningful string form.
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%v", value)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+	}
+	if v, ok := value.(fmt.Stringer); ok {
+		return v.String()
 	}
-	return fmt.Sprintf("%v", value)
+	if rv.Kind() == reflect.Ptr {
+		return coerceString(rv.Elem().Interface())
+	}
+	return nil
+}
+
+// marshalText returns the text form of the value, or nil if it can't be
+// marshaled.
+func marshalText(v encoding.TextMarshaler) interface{} {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil
+	}
+	return string(text)
 }
 
 // String is the GraphQL string type definition
-var String = NewScalar(ScalarConfig{
+var String = newBuiltinScalar(ScalarConfig{
 	Name: "String",
 	Description: "The `String` scalar type represents textual data, represented as UTF-8 " +
 		"character sequences. The String type is most often used by GraphQL to " +
@@ -203,15 +290,24 @@ func coerceBool(value interface{}) interface{} {
 	case uint64:
 		return v != 0
 	}
-	return false
+	return nil
+}
+
+// parseBool only accepts bools as input values. Lenient coercion of 0/1 and
+// "true"/"false" is opt-in (see Params.LenientBooleans).
+func parseBool(value interface{}) interface{} {
+	if v, ok := value.(bool); ok {
+		return v
+	}
+	return nil
 }
 
 // Boolean is the GraphQL boolean type definition
-var Boolean = NewScalar(ScalarConfig{
+var Boolean = newBuiltinScalar(ScalarConfig{
 	Name:        "Boolean",
 	Description: "The `Boolean` scalar type represents `true` or `false`.",
 	Serialize:   coerceBool,
-	ParseValue:  coerceBool,
+	ParseValue:  parseBool,
 	ParseLiteral: func(valueAST ast.Value) interface{} {
 		switch valueAST := valueAST.(type) {
 		case *ast.BooleanValue:
@@ -222,7 +318,7 @@ var Boolean = NewScalar(ScalarConfig{
 })
 
 // ID is the GraphQL id type definition
-var ID = NewScalar(ScalarConfig{
+var ID = newBuiltinScalar(ScalarConfig{
 	Name: "ID",
 	Description: "The `ID` scalar type represents a unique identifier, often used to " +
 		"refetch an object or as key for a cache. The ID type appears in a JSON " +
@@ -241,3 +337,330 @@ var ID = NewScalar(ScalarConfig{
 		return nil
 	},
 })
+
+// IDConfig options for creating a customized ID scalar with NewID.
+type IDConfig struct {
+	// Name must be unique within the schema, e.g. "UUID".
+	Name        string
+	Description string
+	// Parse converts an ID received as input (as a string) to its internal
+	// value. It returns false if the ID is not valid for this scalar.
+	Parse func(id string) (interface{}, bool)
+	// Serialize converts an internal value to the ID returned in a response.
+	// If nil, values are serialized the same way as ID.
+	Serialize func(value interface{}) string
+}
+
+// NewID returns a scalar that is represented like ID (accepting string or
+// integer input and serializing as a string) but has its own name and
+// input validation. It allows fields to use different ID formats (e.g. UUIDs
+// and numeric IDs) without replacing the ID scalar.
+func NewID(config IDConfig) *Scalar {
+	description := config.Description
+	if description == "" {
+		description = ID.Description()
+	}
+	parse := func(id interface{}) interface{} {
+		s, ok := id.(string)
+		if !ok || config.Parse == nil {
+			return id
+		}
+		if v, ok := config.Parse(s); ok {
+			return v
+		}
+		return nil
+	}
+	serialize := coerceString
+	if config.Serialize != nil {
+		serialize = func(value interface{}) interface{} {
+			return config.Serialize(value)
+		}
+	}
+	return NewScalar(ScalarConfig{
+		Name:        config.Name,
+		Description: description,
+		Serialize:   serialize,
+		ParseValue: func(value interface{}) interface{} {
+			if value == nil {
+				return nil
+			}
+			return parse(coerceString(value))
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return parse(ID.ParseLiteral(valueAST))
+		},
+	})
+}
+
+// coerceInt64 converts the value to an int64, or returns nil if it isn't a
+// whole number that fits in one.
+func coerceInt64(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint:
+		return coerceInt64(uint64(v))
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil
+		}
+		return int64(v)
+	case float32:
+		return coerceInt64(float64(v))
+	case float64:
+		// 2^63 is exactly representable as a float64 but isn't an int64.
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return nil
+		}
+		return int64(v)
+	case string:
+		val, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return val
+	case json.Number:
+		return coerceInt64(string(v))
+	case *big.Int:
+		if v == nil || !v.IsInt64() {
+			return nil
+		}
+		return v.Int64()
+	}
+	return nil
+}
+
+// Int64 is a scalar for signed whole numbers that don't fit in an Int. Values
+// are serialized as numbers, and input may be given as a number or a string.
+var Int64 = newBuiltinScalar(ScalarConfig{
+	Name: "Int64",
+	Description: "The `Int64` scalar type represents non-fractional signed whole numeric " +
+		"values between -(2^63) and 2^63 - 1. It may be given as a number or as a string.",
+	Serialize:  coerceInt64,
+	ParseValue: coerceInt64,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return coerceInt64(valueAST.Value)
+		case *ast.StringValue:
+			return coerceInt64(valueAST.Value)
+		}
+		return nil
+	},
+})
+
+// coerceBigInt converts the value to a *big.Int, or returns nil if it isn't a
+// whole number.
+func coerceBigInt(value interface{}) *big.Int {
+	switch v := value.(type) {
+	case *big.Int:
+		return v
+	case big.Int:
+		return &v
+	case string:
+		i, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil
+		}
+		return i
+	case json.Number:
+		return coerceBigInt(string(v))
+	case uint:
+		return new(big.Int).SetUint64(uint64(v))
+	case uint64:
+		return new(big.Int).SetUint64(v)
+	case float32:
+		return coerceBigInt(float64(v))
+	case float64:
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return nil
+		}
+		i, _ := big.NewFloat(v).Int(nil)
+		return i
+	}
+	if i, ok := coerceInt64(value).(int64); ok {
+		return big.NewInt(i)
+	}
+	return nil
+}
+
+// parseBigInt returns the value as a *big.Int, or nil if it isn't a whole
+// number.
+func parseBigInt(value interface{}) interface{} {
+	if i := coerceBigInt(value); i != nil {
+		return i
+	}
+	return nil
+}
+
+// BigInt is a scalar for signed whole numbers of any size, represented by
+// *big.Int. Values are serialized as decimal strings so that they don't lose
+// precision, and input may be given as a number or a string.
+var BigInt = newBuiltinScalar(ScalarConfig{
+	Name: "BigInt",
+	Description: "The `BigInt` scalar type represents non-fractional signed whole numeric " +
+		"values of any size. It's serialized as a string, and may be given as a number or as a string.",
+	Serialize: func(value interface{}) interface{} {
+		if i := coerceBigInt(value); i != nil {
+			return i.String()
+		}
+		return nil
+	},
+	ParseValue: parseBigInt,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return parseBigInt(valueAST.Value)
+		case *ast.StringValue:
+			return parseBigInt(valueAST.Value)
+		}
+		return nil
+	},
+})
+
+// newTimeScalar returns a scalar for time.Time values represented as strings
+// in the given layout. Serialized values are formatted with the layout, which
+// drops any components it doesn't include, and input that doesn't match the
+// layout is rejected.
+func newTimeScalar(name, description, layout string) *Scalar {
+	parse := func(value interface{}) interface{} {
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case *string:
+			if v == nil {
+				return nil
+			}
+			s = *v
+		default:
+			return nil
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return nil
+		}
+		return t
+	}
+	return newBuiltinScalar(ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case time.Time:
+				return v.Format(layout)
+			case *time.Time:
+				if v == nil {
+					return nil
+				}
+				return v.Format(layout)
+			}
+			if t, ok := parse(value).(time.Time); ok {
+				return t.Format(layout)
+			}
+			return nil
+		},
+		ParseValue: parse,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if valueAST, ok := valueAST.(*ast.StringValue); ok {
+				return parse(valueAST.Value)
+			}
+			return nil
+		},
+	})
+}
+
+// DateTime is a scalar for time.Time values serialized as RFC 3339 strings.
+var DateTime = newTimeScalar("DateTime",
+	"The `DateTime` scalar type represents an instant in time as an RFC 3339 string "+
+		"(e.g. \"2006-01-02T15:04:05Z\").",
+	time.RFC3339Nano)
+
+// Date is a scalar for the calendar date of time.Time values, serialized as
+// YYYY-MM-DD strings. Parsed dates are at midnight UTC.
+var Date = newTimeScalar("Date",
+	"The `Date` scalar type represents a calendar date as a YYYY-MM-DD string.",
+	"2006-01-02")
+
+// Time is a scalar for the time of day of time.Time values, serialized as
+// HH:MM:SS strings. Parsed times are on January 1st of year 0 in UTC.
+var Time = newTimeScalar("Time",
+	"The `Time` scalar type represents a time of day as an HH:MM:SS string.",
+	"15:04:05")
+
+// BytesConfig options for creating a scalar for binary data with NewBytes.
+type BytesConfig struct {
+	// Name must be unique within the schema, e.g. "Base64URL".
+	Name        string
+	Description string
+	// URLEncoding selects the URL and filename safe base64 alphabet (RFC 4648
+	// section 5) instead of the standard one.
+	URLEncoding bool
+}
+
+// NewBytes returns a scalar for []byte values represented as base64 strings.
+// Values are serialized with padding, and input is accepted with or without
+// it. Input that isn't valid base64 is rejected.
+func NewBytes(config BytesConfig) *Scalar {
+	enc := base64.StdEncoding
+	if config.URLEncoding {
+		enc = base64.URLEncoding
+	}
+	description := config.Description
+	if description == "" {
+		description = "The `" + config.Name + "` scalar type represents binary data as a base64 string."
+	}
+	parse := func(value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		dec := enc
+		if !strings.HasSuffix(s, "=") {
+			dec = enc.WithPadding(base64.NoPadding)
+		}
+		b, err := dec.DecodeString(s)
+		if err != nil {
+			return nil
+		}
+		return b
+	}
+	return newBuiltinScalar(ScalarConfig{
+		Name:        config.Name,
+		Description: description,
+		Serialize: func(value interface{}) interface{} {
+			if b, ok := value.([]byte); ok {
+				return enc.EncodeToString(b)
+			}
+			return nil
+		},
+		ParseValue: parse,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if valueAST, ok := valueAST.(*ast.StringValue); ok {
+				return parse(valueAST.Value)
+			}
+			return nil
+		},
+	})
+}
+
+// Bytes is a scalar for []byte values represented as strings in the standard
+// base64 encoding.
+var Bytes = NewBytes(BytesConfig{
+	Name:        "Bytes",
+	Description: "The `Bytes` scalar type represents binary data as a base64 string (RFC 4648).",
+})
@@ -2,10 +2,19 @@ package graphql_test
 
 import (
 	"math"
+	"math/big"
+	"net"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
 )
 
 type intSerializationTest struct {
@@ -75,6 +84,341 @@ func TestTypeSystem_Scalar_SerializesOutputInt(t *testing.T) {
 	}
 }
 
+func TestTypeSystem_Scalar_SerializesOutputInt64(t *testing.T) {
+	tests := []intSerializationTest{
+		{1, int64(1)},
+		{-1, int64(-1)},
+		// Beyond 2^31
+		{int64(math.MaxInt32) + 1, int64(math.MaxInt32) + 1},
+		{uint32(math.MaxUint32), int64(math.MaxUint32)},
+		{float64(1e10), int64(1e10)},
+		{"-9876543210", int64(-9876543210)},
+		{int64(math.MaxInt64), int64(math.MaxInt64)},
+		{int64(math.MinInt64), int64(math.MinInt64)},
+		{big.NewInt(math.MaxInt64), int64(math.MaxInt64)},
+		// Beyond 2^63
+		{uint64(math.MaxInt64) + 1, nil},
+		{float64(1 << 63), nil},
+		{"9223372036854775808", nil},
+		{new(big.Int).Lsh(big.NewInt(1), 63), nil},
+		// Fractional
+		{float64(1.1), nil},
+		{"1.1", nil},
+		{"one", nil},
+		{true, nil},
+	}
+
+	for _, test := range tests {
+		val := graphql.Int64.Serialize(test.Value)
+		if val != test.Expected {
+			reflectedValue := reflect.ValueOf(test.Value)
+			t.Fatalf("Failed Int64.Serialize(%v(%v)), expected: %v, got %v", reflectedValue.Type(), test.Value, test.Expected, val)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_SerializesOutputBigInt(t *testing.T) {
+	tests := []intSerializationTest{
+		{1, "1"},
+		{int64(math.MaxInt32) + 1, "2147483648"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{new(big.Int).Lsh(big.NewInt(1), 100), "1267650600228229401496703205376"},
+		{"-1267650600228229401496703205376", "-1267650600228229401496703205376"},
+		{float64(1e20), "100000000000000000000"},
+		{float64(1.5), nil},
+		{"1.5", nil},
+		{"one", nil},
+	}
+
+	for _, test := range tests {
+		val := graphql.BigInt.Serialize(test.Value)
+		if val != test.Expected {
+			reflectedValue := reflect.ValueOf(test.Value)
+			t.Fatalf("Failed BigInt.Serialize(%v(%v)), expected: %v, got %v", reflectedValue.Type(), test.Value, test.Expected, val)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_ParsesInt64AndBigIntInput(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"int64": &graphql.Field{
+					Type: graphql.Int64,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.Int64},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["value"].(int64) + 1, nil
+					},
+				},
+				"bigInt": &graphql.Field{
+					Type: graphql.BigInt,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{Type: graphql.BigInt},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return new(big.Int).Add(p.Args["value"].(*big.Int), big.NewInt(1)), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($big: BigInt, $int64: Int64) {
+			beyond31: int64(value: 2147483648)
+			asString: int64(value: "-9223372036854775808")
+			fromVariable: int64(value: $int64)
+			beyond63: bigInt(value: 9223372036854775807)
+			bigFromVariable: bigInt(value: $big)
+		}`,
+		VariableValues: map[string]interface{}{
+			"int64": "4294967296",
+			"big":   "-18446744073709551617",
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"beyond31":        int64(2147483649),
+			"asString":        int64(-9223372036854775807),
+			"fromVariable":    int64(4294967297),
+			"beyond63":        "9223372036854775808",
+			"bigFromVariable": "-18446744073709551616",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Fractional and out of range literals are rejected.
+	result = graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			int64(value: 1.5)
+			tooBig: int64(value: 9223372036854775808)
+			bigInt(value: 1.0)
+		}`,
+	})
+	if len(result.Errors) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", result.Errors)
+	}
+}
+
+func TestTypeSystem_Scalar_SerializesOutputTimes(t *testing.T) {
+	ts := time.Date(2006, time.January, 2, 15, 4, 5, 600, time.FixedZone("", -7*3600))
+	tests := []struct {
+		Scalar   *graphql.Scalar
+		Value    interface{}
+		Expected interface{}
+	}{
+		{graphql.DateTime, ts, "2006-01-02T15:04:05.0000006-07:00"},
+		{graphql.DateTime, &ts, "2006-01-02T15:04:05.0000006-07:00"},
+		{graphql.DateTime, "2006-01-02T22:04:05Z", "2006-01-02T22:04:05Z"},
+		{graphql.DateTime, "2006-01-02", nil},
+		{graphql.Date, ts, "2006-01-02"},
+		{graphql.Date, "2006-01-02", "2006-01-02"},
+		{graphql.Date, "2006-13-02", nil},
+		{graphql.Time, ts, "15:04:05"},
+		{graphql.Time, "15:04:05", "15:04:05"},
+		{graphql.Time, "3:04pm", nil},
+		{graphql.Time, (*time.Time)(nil), nil},
+		{graphql.Time, 1136214245, nil},
+	}
+	for i, test := range tests {
+		if val := test.Scalar.Serialize(test.Value); val != test.Expected {
+			t.Fatalf("Failed test #%d - %v.Serialize(%#v), expected: %#v, got %#v", i, test.Scalar, test.Value, test.Expected, val)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_ParsesTimeInput(t *testing.T) {
+	field := func(ttype *graphql.Scalar) *graphql.Field {
+		return &graphql.Field{
+			Type: ttype,
+			Args: graphql.FieldConfigArgument{
+				"value": &graphql.ArgumentConfig{Type: ttype},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Args["value"].(time.Time).Add(time.Hour), nil
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"dateTime": field(graphql.DateTime),
+				"date":     field(graphql.Date),
+				"time":     field(graphql.Time),
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($date: Date, $time: Time) {
+			dateTime(value: "2006-01-02T15:04:05-07:00")
+			date(value: "2006-01-02")
+			dateFromVariable: date(value: $date)
+			time(value: "15:04:05")
+			timeFromVariable: time(value: $time)
+		}`,
+		VariableValues: map[string]interface{}{
+			"date": "2016-02-29",
+			"time": "23:30:00",
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"dateTime":         "2006-01-02T16:04:05-07:00",
+			"date":             "2006-01-02",
+			"dateFromVariable": "2016-02-29",
+			"time":             "16:04:05",
+			"timeFromVariable": "00:30:00",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Malformed literals and variables are rejected.
+	result = graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			dateTime(value: "2006-01-02 15:04:05")
+			date(value: "2006-02-30")
+			time(value: 150405)
+		}`,
+	})
+	if len(result.Errors) != 3 {
+		t.Fatalf("Expected 3 errors, got %v", result.Errors)
+	}
+	for _, variables := range []map[string]interface{}{
+		{"date": "01/02/2006"},
+		{"time": "24:00:00"},
+	} {
+		result = graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query ($date: Date, $time: Time) { date(value: $date) time(value: $time) }`,
+			VariableValues: variables,
+		})
+		if result.Data != nil || len(result.Errors) != 1 {
+			t.Fatalf("Expected the variables %v to be rejected, got %v", variables, result)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_SerializesOutputBytes(t *testing.T) {
+	base64URL := graphql.NewBytes(graphql.BytesConfig{Name: "Base64URL", URLEncoding: true})
+	tests := []struct {
+		Scalar   *graphql.Scalar
+		Value    interface{}
+		Expected interface{}
+	}{
+		{graphql.Bytes, []byte("hi"), "aGk="},
+		{graphql.Bytes, []byte{0xfb, 0xff, 0xbf}, "+/+/"},
+		{graphql.Bytes, []byte{}, ""},
+		{graphql.Bytes, "hi", nil},
+		{base64URL, []byte("hi"), "aGk="},
+		{base64URL, []byte{0xfb, 0xff, 0xbf}, "-_-_"},
+	}
+	for i, test := range tests {
+		if val := test.Scalar.Serialize(test.Value); val != test.Expected {
+			t.Fatalf("Failed test #%d - %v.Serialize(%#v), expected: %#v, got %#v", i, test.Scalar, test.Value, test.Expected, val)
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_ParsesBytesInput(t *testing.T) {
+	field := func(ttype *graphql.Scalar) *graphql.Field {
+		return &graphql.Field{
+			Type: ttype,
+			Args: graphql.FieldConfigArgument{
+				"value": &graphql.ArgumentConfig{Type: ttype},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Args["value"], nil
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"bytes":     field(graphql.Bytes),
+				"base64URL": field(graphql.NewBytes(graphql.BytesConfig{Name: "Base64URL", URLEncoding: true})),
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($bytes: Bytes, $base64URL: Base64URL) {
+			bytes(value: "+/+/")
+			unpadded: bytes(value: "aGk")
+			bytesFromVariable: bytes(value: $bytes)
+			base64URL(value: "-_-_")
+			base64URLFromVariable: base64URL(value: $base64URL)
+		}`,
+		VariableValues: map[string]interface{}{
+			"bytes":     "aGk=",
+			"base64URL": "aGk",
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"bytes":                 "+/+/",
+			"unpadded":              "aGk=",
+			"bytesFromVariable":     "aGk=",
+			"base64URL":             "-_-_",
+			"base64URLFromVariable": "aGk=",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// Malformed literals and variables are rejected.
+	result = graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			bytes(value: "-_-_")
+			invalid: bytes(value: "not base64!")
+			base64URL(value: "+/+/")
+			number: base64URL(value: 1234)
+		}`,
+	})
+	if len(result.Errors) != 4 {
+		t.Fatalf("Expected 4 errors, got %v", result.Errors)
+	}
+	for _, variables := range []map[string]interface{}{
+		{"bytes": "a"},
+		{"bytes": []byte("hi")},
+		{"base64URL": "aGk=="},
+	} {
+		result = graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query ($bytes: Bytes, $base64URL: Base64URL) { bytes(value: $bytes) base64URL(value: $base64URL) }`,
+			VariableValues: variables,
+		})
+		if result.Data != nil || len(result.Errors) != 1 {
+			t.Fatalf("Expected the variables %v to be rejected, got %v", variables, result)
+		}
+	}
+}
+
 func TestTypeSystem_Scalar_SerializesOutputFloat(t *testing.T) {
 	tests := []float64SerializationTest{
 		{int(1), float64(1.0)},
@@ -136,3 +480,311 @@ func TestTypeSystem_Scalar_SerializesOutputBoolean(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeSystem_Scalar_NewIDCustomizesCoercionPerField(t *testing.T) {
+	numericID := graphql.NewID(graphql.IDConfig{
+		Name: "NumericID",
+		Parse: func(id string) (interface{}, bool) {
+			n, err := strconv.ParseInt(id, 10, 64)
+			return n, err == nil
+		},
+	})
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	uuidID := graphql.NewID(graphql.IDConfig{
+		Name: "UUID",
+		Parse: func(id string) (interface{}, bool) {
+			return id, uuidPattern.MatchString(id)
+		},
+		Serialize: func(value interface{}) string {
+			return strings.ToLower(value.(string))
+		},
+	})
+
+	echo := func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Args["id"], nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type:    numericID,
+					Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: numericID}},
+					Resolve: echo,
+				},
+				"document": &graphql.Field{
+					Type:    uuidID,
+					Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: uuidID}},
+					Resolve: echo,
+				},
+				"rawDocument": &graphql.Field{
+					Type: uuidID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "123E4567-E89B-12D3-A456-426614174000", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($doc: UUID) {
+			user(id: 42)
+			document(id: $doc)
+			rawDocument
+		}`,
+		VariableValues: map[string]interface{}{"doc": "123e4567-e89b-12d3-a456-426614174000"},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user":        "42",
+			"document":    "123e4567-e89b-12d3-a456-426614174000",
+			"rawDocument": "123e4567-e89b-12d3-a456-426614174000",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user(id: "abc") document(id: "42") }`,
+	})
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected both invalid IDs to be rejected, got %v", result.Errors)
+	}
+}
+
+func TestTypeSystem_Scalar_SerializesTextMarshalers(t *testing.T) {
+	ip := net.IPv4(192, 168, 0, 1)
+	ipAddress := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "IPAddress",
+		Serialize: func(value interface{}) interface{} {
+			if s, ok := value.(string); ok {
+				return s
+			}
+			return nil
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return value
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"address": &graphql.Field{
+					Type: ipAddress,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return ip, nil
+					},
+				},
+				"addressString": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return ip, nil
+					},
+				},
+				"addressID": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return ip, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ address addressString addressID }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"address":       "192.168.0.1",
+			"addressString": "192.168.0.1",
+			"addressID":     "192.168.0.1",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypeSystem_Scalar_DoesNotSerializeTextMarshalersAsNumbers(t *testing.T) {
+	ip := net.IPv4(127, 0, 0, 1)
+	resolveIP := func(p graphql.ResolveParams) (interface{}, error) {
+		return ip, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"n": &graphql.Field{Type: graphql.Int, Resolve: resolveIP},
+				"f": &graphql.Field{Type: graphql.Float, Resolve: resolveIP},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ n f }`,
+	})
+	expectedData := map[string]interface{}{"n": nil, "f": nil}
+	if !reflect.DeepEqual(expectedData, result.Data) {
+		t.Fatalf("Unexpected data, Diff: %v", testutil.Diff(expectedData, result.Data))
+	}
+	var messages []string
+	for _, err := range result.Errors {
+		messages = append(messages, err.Message)
+	}
+	sort.Strings(messages)
+	expectedMessages := []string{
+		"Float cannot represent a value of type net.IP.",
+		"Int cannot represent a value of type net.IP.",
+	}
+	if !reflect.DeepEqual(expectedMessages, messages) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedMessages, messages))
+	}
+}
+
+func TestTypeSystem_Scalar_ReportsValuesBuiltinScalarsCantSerialize(t *testing.T) {
+	type unserializable struct{ A int }
+	scalars := []*graphql.Scalar{
+		graphql.Int, graphql.Float, graphql.String, graphql.Boolean, graphql.ID,
+		graphql.Int64, graphql.BigInt, graphql.DateTime, graphql.Date, graphql.Time,
+		graphql.Bytes,
+	}
+	values := []interface{}{
+		unserializable{A: 1},
+		&unserializable{A: 1},
+		map[string]interface{}{"a": 1},
+		[]int{1},
+		make(chan int),
+	}
+	for _, scalar := range scalars {
+		for _, value := range values {
+			if v := scalar.Serialize(value); v != nil {
+				t.Errorf("%s.Serialize(%T) = %v, expected nil", scalar.Name(), value, v)
+			}
+
+			value := value
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name: "Query",
+					Fields: graphql.Fields{
+						"value": &graphql.Field{
+							Type: scalar,
+							Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+								return value, nil
+							},
+						},
+					},
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: `{ value }`,
+			})
+			expected := map[string]interface{}{"value": nil}
+			if !reflect.DeepEqual(expected, result.Data) {
+				t.Errorf("%s with %T: unexpected data, Diff: %v", scalar.Name(), value, testutil.Diff(expected, result.Data))
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("%s with %T: expected 1 error, got %v", scalar.Name(), value, result.Errors)
+			}
+			resultErr := result.Errors[0]
+			message := scalar.Name() + " cannot represent a value of type " + reflect.TypeOf(value).String() + "."
+			if resultErr.Message != message {
+				t.Errorf("%s with %T: expected message %q, got %q", scalar.Name(), value, message, resultErr.Message)
+			}
+			if !reflect.DeepEqual([]interface{}{"value"}, resultErr.Path) {
+				t.Errorf("%s with %T: unexpected path %v", scalar.Name(), value, resultErr.Path)
+			}
+			if _, ok := resultErr.OriginalError.(*graphql.SerializationError); !ok {
+				t.Errorf("%s with %T: expected a SerializationError, got %T", scalar.Name(), value, resultErr.OriginalError)
+			}
+		}
+	}
+}
+
+func TestTypeSystem_Scalar_SerializesPointers(t *testing.T) {
+	i := 1
+	f := 1.5
+	b := true
+	s := "s"
+	var nilTime *time.Time
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"int": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &i, nil
+					},
+				},
+				"float": &graphql.Field{
+					Type: graphql.Float,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &f, nil
+					},
+				},
+				"boolean": &graphql.Field{
+					Type: graphql.Boolean,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &b, nil
+					},
+				},
+				"string": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &s, nil
+					},
+				},
+				"nilTime": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nilTime, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ int float boolean string nilTime }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"int":     1,
+			"float":   1.5,
+			"boolean": true,
+			"string":  "s",
+			"nilTime": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -2,21 +2,64 @@ package graphql
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 )
 
 type SchemaConfig struct {
+	Description  string
 	Query        *Object
 	Mutation     *Object
 	Subscription *Object
 	Types        []Type
 	Directives   []*Directive
+
+	// TypeLoader, if set, loads types by name the first time they're looked up
+	// (e.g. by a fragment's type condition, a variable definition, or __type)
+	// rather than NewSchema walking and validating every type reachable from the
+	// root types up front. Only the root types, Types, and the introspection types
+	// are registered and validated when the schema is created, and each loaded
+	// type is validated as it's loaded (see Schema.LoadType). TypeMap and the
+	// possible types of an interface only include the types that have been
+	// loaded so far.
+	TypeLoader TypeLoader
+
+	// IsVisible, if set, reports whether a type or a field is visible to
+	// clients. kind is VisibilityType with the name of a type, or
+	// VisibilityField with the coordinate of a field of an object or interface
+	// (e.g. "User.email"). Hidden types and fields are left out of
+	// introspection, a hidden field can't be queried as though it didn't exist,
+	// and a hidden type can't be named in a fragment or variable definition.
+	// The visible parts of the schema must be complete, so NewSchema rejects
+	// visible fields, arguments, and input fields of a hidden type, as well as
+	// hidden fields that implement a visible interface field. To show
+	// different clients different parts of the same types, create a schema
+	// for each.
+	IsVisible func(kind, name string) bool
+
+	// ResolveMethods, if set, makes fields without a Resolve function call a
+	// zero-argument method of the source named after the field (e.g. Name()
+	// for name) when the source has no matching map key or struct field. The
+	// method must return a value, or a value and an error. Methods with a
+	// pointer receiver are found on struct values as well as pointers.
+	ResolveMethods bool
 }
 
+// Kinds of schema elements passed to SchemaConfig.IsVisible.
+const (
+	VisibilityType  = "TYPE"
+	VisibilityField = "FIELD"
+)
+
 type TypeMap map[string]Type
 
+// TypeLoader returns the type with the given name, or nil if there's no such type.
+type TypeLoader func(name string) (Type, error)
+
 // Schema Definition
 // A Schema is created by supplying the root types of each type of operation,
 // query, mutation (optional) and subscription (optional). A schema definition is then supplied to the
@@ -38,23 +81,47 @@ type TypeMap map[string]Type
 //       directives: specifiedDirectives.concat([ myCustomDirective ]),
 //     })
 type Schema struct {
-	typeMap    TypeMap
-	directives []*Directive
+	id          uint64 // unique to each schema created by NewSchema
+	description string
+	typeMap     TypeMap
+	directives  []*Directive
 
 	queryType        *Object
 	mutationType     *Object
 	subscriptionType *Object
 	implementations  map[string][]*Object
 	possibleTypeMap  *sync.Map // abstract type name -> map[string]struct{}
+
+	introspectionCache *introspectionCache // nil if types are loaded lazily
+
+	typeLoader  TypeLoader
+	loadedTypes *sync.Map // type name -> Type
+
+	isVisible    func(kind, name string) bool
+	hiddenFields *sync.Map // type name -> map[string]bool of the fields IsVisible hides
+
+	resolveMethods bool
 }
 
+// lastSchemaID is the id of the most recently created schema.
+var lastSchemaID uint64
+
+// errMissingQueryType is the message of the error returned when creating or
+// using a schema without a query type.
+const errMissingQueryType = "Schema must contain a Query root type."
+
+// NewSchema returns a schema with the given root types. A Query type is
+// required, so a schema with only a Mutation type is rejected.
 func NewSchema(config SchemaConfig) (Schema, error) {
 	schema := Schema{
+		id:              atomic.AddUint64(&lastSchemaID, 1),
 		possibleTypeMap: &sync.Map{},
 	}
 
+	// The spec requires a query type, even for a schema that's only used for
+	// mutations.
 	if config.Query == nil {
-		return schema, gqlerrors.NewFormattedError("Schema query must be Object Type but got: nil.")
+		return schema, gqlerrors.NewFormattedError(errMissingQueryType)
 	}
 
 	// if schema config contains error at creation time, return those errors
@@ -65,9 +132,15 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		return schema, config.Mutation.Error()
 	}
 
+	schema.description = config.Description
 	schema.queryType = config.Query
 	schema.mutationType = config.Mutation
 	schema.subscriptionType = config.Subscription
+	schema.resolveMethods = config.ResolveMethods
+	if config.IsVisible != nil {
+		schema.isVisible = config.IsVisible
+		schema.hiddenFields = &sync.Map{}
+	}
 
 	// Provide specified directives (e.g. @include and @skip) by default.
 	schema.directives = config.Directives
@@ -102,10 +175,24 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		initialTypes = append(initialTypes, ttype)
 	}
 
+	if config.TypeLoader != nil {
+		schema.typeLoader = config.TypeLoader
+		schema.loadedTypes = &sync.Map{}
+	} else {
+		// The result of __schema changes as types are loaded, so it's only
+		// cached when all types are known up front.
+		schema.introspectionCache = &introspectionCache{entries: make(map[string]interface{})}
+	}
+
 	for _, ttype := range initialTypes {
 		if ttype.Error() != nil {
 			return schema, ttype.Error()
 		}
+		if schema.typeLoader != nil && ttype != SchemaType {
+			// Register the type without walking its fields
+			typeMap[ttype.Name()] = ttype
+			continue
+		}
 		var err error
 		typeMap, err = typeMapReducer(&schema, typeMap, ttype)
 		if err != nil {
@@ -114,32 +201,192 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	}
 
 	schema.typeMap = typeMap
+	for _, ttype := range typeMap {
+		schema.recordHiddenFields(ttype)
+	}
 
-	// Keep track of all implementations by interface name.
+	// Keep track of all implementations by interface name, in order of the
+	// name of the implementing type.
 	if schema.implementations == nil {
 		schema.implementations = map[string][]*Object{}
 	}
-	for _, ttype := range schema.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
+	typeNames := make([]string, 0, len(schema.typeMap))
+	for name := range schema.typeMap {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		if ttype, ok := schema.typeMap[name].(*Object); ok {
 			for _, iface := range ttype.Interfaces() {
 				schema.implementations[iface.Name()] = append(schema.implementations[iface.Name()], ttype)
 			}
 		}
 	}
 
-	// Enforce correct interface implementations
-	for _, ttype := range schema.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
-			for _, iface := range ttype.Interfaces() {
-				err := assertObjectImplementsInterface(&schema, ttype, iface)
-				if err != nil {
-					return schema, err
+	if errs := validateSchema(&schema); len(errs) != 0 {
+		if len(errs) == 1 {
+			return schema, errs[0]
+		}
+		return schema, errs
+	}
+
+	return schema, nil
+}
+
+// SchemaErrors is returned by NewSchema when the assembled type system has more
+// than one problem.
+type SchemaErrors []gqlerrors.FormattedError
+
+func (errs SchemaErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Message
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// validateSchema checks that field, argument, and input field types are of the
+// right kind and that objects correctly implement their interfaces. It returns
+// every problem found.
+func validateSchema(schema *Schema) SchemaErrors {
+	names := make([]string, 0, len(schema.typeMap))
+	for name := range schema.typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs SchemaErrors
+	if schema.isVisible != nil {
+		for _, root := range []*Object{schema.queryType, schema.mutationType, schema.subscriptionType} {
+			if root != nil && root.Name() != "" && !schema.typeVisible(root.Name()) {
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Root type "%v" cannot be hidden.`, root.Name())))
+			}
+		}
+	}
+	for _, name := range names {
+		errs = append(errs, validateType(schema, schema.typeMap[name])...)
+	}
+	return errs
+}
+
+// validateType checks a single type of the schema for validateSchema. It's also
+// used to check the types loaded by a TypeLoader as they're loaded.
+func validateType(schema *Schema, ttype Type) SchemaErrors {
+	var errs SchemaErrors
+	if schema.isVisible != nil {
+		errs = append(errs, validateVisibility(schema, ttype)...)
+	}
+	switch ttype := ttype.(type) {
+	case *Object:
+		errs = append(errs, validateFieldTypes(ttype, ttype.Fields())...)
+		errs = append(errs, assertImplementsInterfaces(schema, ttype, ttype.Fields(), ttype.Interfaces())...)
+	case *Interface:
+		errs = append(errs, validateFieldTypes(ttype, ttype.Fields())...)
+		errs = append(errs, assertImplementsInterfaces(schema, ttype, ttype.Fields(), ttype.Interfaces())...)
+	case *InputObject:
+		fields := ttype.Fields()
+		for _, fieldName := range sortedInputFieldNames(fields) {
+			if field := fields[fieldName]; !IsInputType(field.Type) {
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v field type must be Input Type but got: %v.`, ttype, fieldName, field.Type)))
+			}
+		}
+	}
+	return errs
+}
+
+// validateVisibility checks that the visible members of a visible type don't
+// reference types hidden by SchemaConfig.IsVisible, and that objects and
+// interfaces don't hide fields of the visible interfaces they implement.
+func validateVisibility(schema *Schema, ttype Type) SchemaErrors {
+	if !schema.typeVisible(ttype.Name()) {
+		return nil
+	}
+	hiddenType := func(t Type) (string, bool) {
+		named, ok := GetNamed(t).(Type)
+		if !ok || named == nil {
+			return "", false
+		}
+		return named.Name(), !schema.typeVisible(named.Name())
+	}
+
+	var errs SchemaErrors
+	var fields FieldDefinitionMap
+	var interfaces []*Interface
+	switch ttype := ttype.(type) {
+	case *Object:
+		fields, interfaces = ttype.Fields(), ttype.Interfaces()
+	case *Interface:
+		fields, interfaces = ttype.Fields(), ttype.Interfaces()
+	case *InputObject:
+		inputFields := ttype.Fields()
+		for _, fieldName := range sortedInputFieldNames(inputFields) {
+			if typeName, hidden := hiddenType(inputFields[fieldName].Type); hidden {
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Input field "%v.%v" is visible but its type "%v" is hidden.`, ttype.Name(), fieldName, typeName)))
+			}
+		}
+		return errs
+	default:
+		return nil
+	}
+	for _, fieldName := range sortedFieldNames(fields) {
+		if !schema.fieldVisible(ttype.Name(), fieldName) {
+			for _, iface := range interfaces {
+				if _, ok := iface.Fields()[fieldName]; ok && schema.typeVisible(iface.Name()) && schema.fieldVisible(iface.Name(), fieldName) {
+					errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Field "%v.%v" is hidden but implements the visible field "%v.%v".`, ttype.Name(), fieldName, iface.Name(), fieldName)))
 				}
 			}
+			continue
+		}
+		field := fields[fieldName]
+		if typeName, hidden := hiddenType(field.Type); hidden {
+			errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Field "%v.%v" is visible but its type "%v" is hidden.`, ttype.Name(), fieldName, typeName)))
+		}
+		for _, arg := range field.Args {
+			if typeName, hidden := hiddenType(arg.Type); hidden {
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Argument "%v.%v(%v:)" is visible but its type "%v" is hidden.`, ttype.Name(), fieldName, arg.PrivateName, typeName)))
+			}
 		}
 	}
+	return errs
+}
 
-	return schema, nil
+func validateFieldTypes(ttype Named, fields FieldDefinitionMap) SchemaErrors {
+	var errs SchemaErrors
+	for _, fieldName := range sortedFieldNames(fields) {
+		field := fields[fieldName]
+		if !IsOutputType(field.Type) {
+			errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v field type must be Output Type but got: %v.`, ttype, fieldName, field.Type)))
+		}
+		for _, arg := range field.Args {
+			if !IsInputType(arg.Type) {
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v(%v:) argument type must be Input Type but got: %v.`, ttype, fieldName, arg.PrivateName, arg.Type)))
+			}
+		}
+	}
+	return errs
+}
+
+func sortedFieldNames(fields FieldDefinitionMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedInputFieldNames(fields InputObjectFieldMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Description returns the description of the schema.
+func (gq *Schema) Description() string {
+	return gq.description
 }
 
 func (gq *Schema) QueryType() *Object {
@@ -168,11 +415,105 @@ func (gq *Schema) Directive(name string) *Directive {
 }
 
 func (gq *Schema) TypeMap() TypeMap {
-	return gq.typeMap
+	if gq.typeLoader == nil {
+		return gq.typeMap
+	}
+	typeMap := make(TypeMap, len(gq.typeMap))
+	for name, ttype := range gq.typeMap {
+		typeMap[name] = ttype
+	}
+	gq.loadedTypes.Range(func(name, ttype interface{}) bool {
+		typeMap[name.(string)] = ttype.(Type)
+		return true
+	})
+	return typeMap
 }
 
+// Type returns the named type, or nil if there's no such type or it fails to
+// load (see LoadType).
 func (gq *Schema) Type(name string) Type {
-	return gq.TypeMap()[name]
+	ttype, _ := gq.LoadType(name)
+	return ttype
+}
+
+// LoadType returns the named type like Type, along with the error if the
+// schema's TypeLoader fails to load it. A loaded type is validated like the
+// types of a schema without a TypeLoader, and it must have the requested name.
+// Types that fail to load aren't cached so they're loaded again on next use.
+func (gq *Schema) LoadType(name string) (Type, error) {
+	if ttype, ok := gq.typeMap[name]; ok {
+		return ttype, nil
+	}
+	if gq.typeLoader == nil {
+		return nil, nil
+	}
+	if ttype, ok := gq.loadedTypes.Load(name); ok {
+		return ttype.(Type), nil
+	}
+	ttype, err := gq.typeLoader(name)
+	if err != nil {
+		return nil, err
+	}
+	if ttype == nil {
+		return nil, nil
+	}
+	if err := ttype.Error(); err != nil {
+		return nil, err
+	}
+	if ttype.Name() != name {
+		return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Type loader returned type "%v" for type "%v".`, ttype.Name(), name))
+	}
+	gq.recordHiddenFields(ttype)
+	if errs := validateType(gq, ttype); len(errs) != 0 {
+		if len(errs) == 1 {
+			return nil, errs[0]
+		}
+		return nil, errs
+	}
+	actual, _ := gq.loadedTypes.LoadOrStore(name, ttype)
+	return actual.(Type), nil
+}
+
+// typeVisible reports whether the named type is visible to clients.
+func (gq *Schema) typeVisible(name string) bool {
+	return gq.isVisible == nil || gq.isVisible(VisibilityType, name)
+}
+
+// fieldVisible reports whether the field of the named type is visible to
+// clients. It's called for every field that's resolved, so IsVisible is only
+// called for fields of types that recordHiddenFields hasn't recorded.
+func (gq *Schema) fieldVisible(typeName, fieldName string) bool {
+	if gq.isVisible == nil {
+		return true
+	}
+	if hidden, ok := gq.hiddenFields.Load(typeName); ok {
+		return !hidden.(map[string]bool)[fieldName]
+	}
+	return gq.isVisible(VisibilityField, typeName+"."+fieldName)
+}
+
+// recordHiddenFields records which fields of an object or interface type are
+// hidden by IsVisible, so that they're only checked once per schema.
+func (gq *Schema) recordHiddenFields(ttype Type) {
+	if gq.isVisible == nil {
+		return
+	}
+	var fields FieldDefinitionMap
+	switch ttype := ttype.(type) {
+	case *Object:
+		fields = ttype.Fields()
+	case *Interface:
+		fields = ttype.Fields()
+	default:
+		return
+	}
+	hidden := make(map[string]bool)
+	for fieldName := range fields {
+		if !gq.isVisible(VisibilityField, ttype.Name()+"."+fieldName) {
+			hidden[fieldName] = true
+		}
+	}
+	gq.hiddenFields.Store(ttype.Name(), hidden)
 }
 
 func (gq *Schema) PossibleTypes(abstractType Abstract) []*Object {
@@ -180,13 +521,40 @@ func (gq *Schema) PossibleTypes(abstractType Abstract) []*Object {
 	case *Union:
 		return abstractType.Types()
 	case *Interface:
+		if gq.typeLoader != nil {
+			var impls []*Object
+			for _, ttype := range gq.TypeMap() {
+				if obj, ok := ttype.(*Object); ok && implementsInterface(obj, abstractType) {
+					impls = append(impls, obj)
+				}
+			}
+			sort.Slice(impls, func(i, j int) bool { return impls[i].Name() < impls[j].Name() })
+			return impls
+		}
 		if impls, ok := gq.implementations[abstractType.Name()]; ok {
 			return impls
 		}
 	}
 	return []*Object{}
 }
+
+// ImplementingTypes returns the object types that implement the interface with
+// the given name sorted by name, or nil if the schema has no such interface.
+func (gq *Schema) ImplementingTypes(name string) []*Object {
+	iface, ok := gq.Type(name).(*Interface)
+	if !ok {
+		return nil
+	}
+	impls := append([]*Object(nil), gq.PossibleTypes(iface)...)
+	sort.Slice(impls, func(i, j int) bool { return impls[i].Name() < impls[j].Name() })
+	return impls
+}
+
 func (gq *Schema) IsPossibleType(abstractType Abstract, possibleType *Object) bool {
+	if iface, ok := abstractType.(*Interface); ok && gq.typeLoader != nil {
+		// The set of implementations grows as types are loaded
+		return implementsInterface(possibleType, iface)
+	}
 	name := abstractType.Name()
 	typeMapVal, _ := gq.possibleTypeMap.Load(name)
 	typeMap, ok := typeMapVal.(map[string]struct{})
@@ -203,6 +571,15 @@ func (gq *Schema) IsPossibleType(abstractType Abstract, possibleType *Object) bo
 	_, isPossible := typeMap[possibleType.Name()]
 	return isPossible
 }
+func implementsInterface(object *Object, iface *Interface) bool {
+	for _, i := range object.Interfaces() {
+		if i.Name() == iface.Name() {
+			return true
+		}
+	}
+	return false
+}
+
 func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap, error) {
 	var err error
 	if objectType == nil || objectType.Name() == "" {
@@ -265,6 +642,19 @@ func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap,
 				return typeMap, err
 			}
 		}
+		interfaces := objectType.Interfaces()
+		if objectType.Error() != nil {
+			return typeMap, objectType.Error()
+		}
+		for _, innerObjectType := range interfaces {
+			if innerObjectType.Error() != nil {
+				return typeMap, innerObjectType.Error()
+			}
+			typeMap, err = typeMapReducer(schema, typeMap, innerObjectType)
+			if err != nil {
+				return typeMap, err
+			}
+		}
 	case *Object:
 		interfaces := objectType.Interfaces()
 		if objectType.Error() != nil {
@@ -331,25 +721,49 @@ func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap,
 	return typeMap, nil
 }
 
-func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Interface) error {
-	objectFieldMap := object.Fields()
+// assertImplementsInterfaces checks that an object or interface implements each
+// of its interfaces, including the interfaces they implement in turn.
+func assertImplementsInterfaces(schema *Schema, impl Type, fields FieldDefinitionMap, interfaces []*Interface) SchemaErrors {
+	var errs SchemaErrors
+	declared := make(map[string]bool, len(interfaces))
+	for _, iface := range interfaces {
+		declared[iface.Name()] = true
+	}
+	for _, iface := range interfaces {
+		if iface.Name() == impl.Name() {
+			errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Type %v cannot implement itself because it would create a circular reference.`, impl)))
+			continue
+		}
+		for _, transitive := range iface.Interfaces() {
+			if !declared[transitive.Name()] {
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`Type %v must implement %v because it is implemented by %v.`, impl, transitive, iface)))
+			}
+		}
+		errs = append(errs, assertImplementsInterface(schema, impl, fields, iface)...)
+	}
+	return errs
+}
+
+func assertImplementsInterface(schema *Schema, object Named, objectFieldMap FieldDefinitionMap, iface *Interface) SchemaErrors {
 	ifaceFieldMap := iface.Fields()
 
+	var errs SchemaErrors
 	// Assert each interface field is implemented.
-	for fieldName := range ifaceFieldMap {
+	for _, fieldName := range sortedFieldNames(ifaceFieldMap) {
 		objectField := objectFieldMap[fieldName]
 		ifaceField := ifaceFieldMap[fieldName]
 
 		// Assert interface field exists on object.
 		if objectField == nil {
-			return gqlerrors.NewFormattedError(fmt.Sprintf(`"%v" expects field "%v" but "%v" does not provide it.`, iface, fieldName, object))
+			errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`"%v" expects field "%v" but "%v" does not provide it.`, iface, fieldName, object)))
+			continue
 		}
 
 		// Assert interface field type matches object field type.
 		if !isTypeSubTypeOf(schema, objectField.Type, ifaceField.Type) {
-			return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v expects type "%v" but %v.%v provides type "%v".`,
+			errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v expects type "%v" but %v.%v provides type "%v".`,
 				iface, fieldName, ifaceField.Type,
-				object, fieldName, objectField.Type))
+				object, fieldName, objectField.Type)))
 		}
 
 		// Assert each interface field arg is implemented.
@@ -364,19 +778,20 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 			}
 			// Assert interface field arg exists on object field.
 			if objectArg == nil {
-				return gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v expects argument "%v" but %v.%v does not provide it.`,
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(`%v.%v expects argument "%v" but %v.%v does not provide it.`,
 					iface, fieldName, argName,
-					object, fieldName))
+					object, fieldName)))
+				continue
 			}
 
 			// Assert interface field arg type matches object field arg type.
 			if !isEqualType(ifaceArg.Type, objectArg.Type) {
-				return gqlerrors.NewFormattedError(fmt.Sprintf(
+				errs = append(errs, gqlerrors.NewFormattedError(fmt.Sprintf(
 					`%v.%v(%v:) expects type "%v" `+
 						`but %v.%v(%v:) provides `+
 						`type "%v".`,
 					iface, fieldName, argName, ifaceArg.Type,
-					object, fieldName, argName, objectArg.Type))
+					object, fieldName, argName, objectArg.Type)))
 			}
 		}
 		// Assert additional arguments must not be required.
@@ -392,14 +807,14 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 			if ifaceArg == nil {
 				_, ok := objectArg.Type.(*NonNull)
 				if ok {
-					return gqlerrors.NewFormattedError(
+					errs = append(errs, gqlerrors.NewFormattedError(
 						fmt.Sprintf(`%v.%v(%v:) is of required type "%v" but is not also provided by the interface %v.%v.`,
-							object, fieldName, argName, objectArg.Type, iface, fieldName))
+							object, fieldName, argName, objectArg.Type, iface, fieldName)))
 				}
 			}
 		}
 	}
-	return nil
+	return errs
 }
 
 func isEqualType(typeA, typeB Type) bool {
@@ -454,11 +869,18 @@ func isTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
 	}
 
 	// If superType type is an abstract type, maybeSubType type may be a currently
-	// possible object type.
+	// possible object type or an interface that implements it.
 	if superType, ok := superType.(*Interface); ok {
 		if maybeSubType, ok := maybeSubType.(*Object); ok && schema.IsPossibleType(superType, maybeSubType) {
 			return true
 		}
+		if maybeSubType, ok := maybeSubType.(*Interface); ok {
+			for _, iface := range maybeSubType.Interfaces() {
+				if iface.Name() == superType.Name() {
+					return true
+				}
+			}
+		}
 	}
 	if superType, ok := superType.(*Union); ok {
 		if maybeSubType, ok := maybeSubType.(*Object); ok && schema.IsPossibleType(superType, maybeSubType) {
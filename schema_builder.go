@@ -0,0 +1,1247 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/language/parser"
+	"github.com/sprucehealth/graphql/language/source"
+)
+
+// BuildSchema parses a schema written in the GraphQL type system definition
+// language and builds a Schema from it. Comments directly preceding a definition
+// become its description. See BuildASTSchema.
+func BuildSchema(sdl string) (Schema, error) {
+	return BuildSchemaWithOptions(sdl, BuildOptions{})
+}
+
+// BuildSchemaWithOptions is like BuildSchema but allows customizing the built schema.
+func BuildSchemaWithOptions(sdl string, opts BuildOptions) (Schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.New("GraphQL schema", sdl),
+		Options: parser.ParseOptions{KeepComments: true},
+	})
+	if err != nil {
+		return Schema{}, err
+	}
+	return BuildASTSchemaWithOptions(doc, opts)
+}
+
+// BuildOptions customizes the schema built from a type system document.
+type BuildOptions struct {
+	// Scalars are the implementations of custom scalars declared in the
+	// document, by name. Default values of arguments and input fields of these
+	// types are parsed with the scalar's ParseLiteral when the schema is built.
+	Scalars map[string]*Scalar
+}
+
+// BuildASTSchema builds a Schema from a parsed type system document. Type
+// extensions (`extend type`, `extend interface`, ...) are merged into the type
+// they extend, and it's an error for an extension to redefine an existing field,
+// input field, or enum value.
+//
+// Directives declared with `directive @name(...) on ...` are added to the
+// specified directives (@include, @skip, and @deprecated), replacing any of them
+// with the same name. Input objects with the @oneOf directive are built as
+// @oneOf input objects (see InputObjectConfig.IsOneOf).
+//
+// The built schema has no resolvers: fields use the default resolver and abstract
+// types resolve values by their `__typename` key. Custom scalars pass values
+// through unchanged unless an implementation is provided (see BuildOptions).
+// Root types are taken from the `schema` definition if there is one, otherwise
+// from the types named Query, Mutation, and Subscription.
+func BuildASTSchema(doc *ast.Document) (Schema, error) {
+	return BuildASTSchemaWithOptions(doc, BuildOptions{})
+}
+
+// BuildASTSchemaWithOptions is like BuildASTSchema but allows customizing the
+// built schema.
+func BuildASTSchemaWithOptions(doc *ast.Document, opts BuildOptions) (Schema, error) {
+	b := newSchemaBuilder(opts.Scalars, nil)
+	schemaDef, directiveDefs, err := b.addDefinitions(doc)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	names := make([]string, 0, len(b.defs))
+	for name := range b.defs {
+		names = append(names, name)
+	}
+	types, err := b.buildTypes(names)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	rootNames := map[string]string{
+		ast.OperationTypeQuery:        "Query",
+		ast.OperationTypeMutation:     "Mutation",
+		ast.OperationTypeSubscription: "Subscription",
+	}
+	if schemaDef != nil {
+		rootNames = make(map[string]string, len(schemaDef.OperationTypes))
+		for _, op := range schemaDef.OperationTypes {
+			rootNames[op.Operation] = op.Type.Name.Value
+		}
+	}
+	custom, err := b.buildDirectives(directiveDefs)
+	if err != nil {
+		return Schema{}, err
+	}
+	defined := make(map[string]bool, len(custom))
+	for _, dir := range custom {
+		defined[dir.Name] = true
+	}
+	directives := make([]*Directive, 0, len(SpecifiedDirectives)+len(custom))
+	for _, dir := range SpecifiedDirectives {
+		if !defined[dir.Name] {
+			directives = append(directives, dir)
+		}
+	}
+	directives = append(directives, custom...)
+
+	config := SchemaConfig{Types: types, Directives: directives}
+	if schemaDef != nil {
+		config.Description = description(schemaDef.Description, schemaDef.Doc)
+	}
+	for op, name := range rootNames {
+		ttype, ok := b.types[name]
+		if !ok {
+			if schemaDef != nil {
+				return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Specified %s type "%s" not found in document.`, op, name))
+			}
+			continue
+		}
+		obj, ok := ttype.(*Object)
+		if !ok {
+			return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Specified %s type "%s" must be an object type.`, op, name))
+		}
+		switch op {
+		case ast.OperationTypeQuery:
+			config.Query = obj
+		case ast.OperationTypeMutation:
+			config.Mutation = obj
+		case ast.OperationTypeSubscription:
+			config.Subscription = obj
+		}
+	}
+	return NewSchema(config)
+}
+
+// ExtendSchema returns a new schema with the type system definitions and
+// extensions written in the GraphQL type system definition language applied to
+// the given schema. See ExtendASTSchema.
+func ExtendSchema(schema Schema, sdl string) (Schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.New("GraphQL schema extension", sdl),
+		Options: parser.ParseOptions{KeepComments: true},
+	})
+	if err != nil {
+		return Schema{}, err
+	}
+	return ExtendASTSchema(schema, doc)
+}
+
+// ExtendASTSchema returns a new schema with the definitions of a parsed type
+// system document applied to the given schema. The document may define new
+// types and directives, and extend the types of the schema or the document
+// with `extend type` (`extend interface`, ...) definitions. It's an error to
+// redefine a type, directive, field, input field, or enum value.
+//
+// The given schema isn't modified. Its types are copied, keeping their
+// resolvers, so that the types of the new schema refer to each other. Schemas
+// with a TypeLoader can't be extended.
+func ExtendASTSchema(schema Schema, doc *ast.Document) (Schema, error) {
+	if schema.typeLoader != nil {
+		return Schema{}, gqlerrors.NewFormattedError("Cannot extend a schema that loads its types with a TypeLoader.")
+	}
+	b := newSchemaBuilder(nil, []*Schema{&schema})
+	schemaDef, directiveDefs, err := b.addDefinitions(doc)
+	if err != nil {
+		return Schema{}, err
+	}
+	if schemaDef != nil {
+		return Schema{}, gqlerrors.NewFormattedError("Cannot define a new schema within a schema extension.")
+	}
+
+	names := b.existingNames()
+	for name := range b.defs {
+		names = append(names, name)
+	}
+	types, err := b.buildTypes(names)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	custom, err := b.buildDirectives(directiveDefs)
+	if err != nil {
+		return Schema{}, err
+	}
+	for _, dir := range custom {
+		if schema.Directive(dir.Name) != nil {
+			return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Directive "@%s" already exists in the schema. It cannot be redefined.`, dir.Name))
+		}
+	}
+
+	config := SchemaConfig{
+		Description: schema.Description(),
+		Types:       types,
+		Directives:  append(append([]*Directive{}, schema.Directives()...), custom...),
+	}
+	if root := schema.QueryType(); root != nil {
+		config.Query = b.types[root.Name()].(*Object)
+	}
+	if root := schema.MutationType(); root != nil {
+		config.Mutation = b.types[root.Name()].(*Object)
+	}
+	if root := schema.SubscriptionType(); root != nil {
+		config.Subscription = b.types[root.Name()].(*Object)
+	}
+	return NewSchema(config)
+}
+
+var builtinScalars = map[string]*Scalar{
+	"Int":     Int,
+	"Float":   Float,
+	"String":  String,
+	"Boolean": Boolean,
+	"ID":      ID,
+}
+
+type schemaBuilder struct {
+	scalars    map[string]*Scalar
+	defs       map[string]ast.TypeDefinition
+	extensions map[string][]ast.TypeDefinition
+	types      map[string]Type
+	// inputFields are the fields of the input objects, returned by their thunks.
+	inputFields map[string]InputObjectConfigFieldMap
+	// interfaces are the interfaces implemented by the interfaces, returned by
+	// their thunks as interfaces may implement each other.
+	interfaces map[string][]*Interface
+	// pending are the types that still need their fields added. Fields are
+	// added after all types are created so that types may refer to each other.
+	pending []string
+	// existing are the types of the schemas that are extended or merged, by
+	// name. The introspection types are left out as they're never copied.
+	existing map[string][]Type
+	// rootNames are the names that the root types of the merged schemas are
+	// copied as, which are the names of the first schema's root types.
+	rootNames map[string]string
+	// copied are the existing types that the pending types are copies of.
+	copied map[string][]Type
+}
+
+// newSchemaBuilder returns a builder for a schema that includes copies of the
+// types of the given schemas, if any.
+func newSchemaBuilder(scalars map[string]*Scalar, schemas []*Schema) *schemaBuilder {
+	b := &schemaBuilder{
+		scalars:     scalars,
+		defs:        make(map[string]ast.TypeDefinition),
+		extensions:  make(map[string][]ast.TypeDefinition),
+		types:       make(map[string]Type),
+		inputFields: make(map[string]InputObjectConfigFieldMap),
+		interfaces:  make(map[string][]*Interface),
+		existing:    make(map[string][]Type),
+		rootNames:   make(map[string]string),
+		copied:      make(map[string][]Type),
+	}
+	var first [3]string
+	for _, schema := range schemas {
+		for i, root := range []*Object{schema.queryType, schema.mutationType, schema.subscriptionType} {
+			if root == nil {
+				continue
+			}
+			if first[i] == "" {
+				first[i] = root.Name()
+			}
+			b.rootNames[root.Name()] = first[i]
+		}
+	}
+	for _, schema := range schemas {
+		for name, ttype := range schema.typeMap {
+			if !strings.HasPrefix(name, "__") {
+				name = b.mergedName(name)
+				b.existing[name] = append(b.existing[name], ttype)
+			}
+		}
+	}
+	return b
+}
+
+// mergedName returns the name of the copy of a type of the merged schemas.
+func (b *schemaBuilder) mergedName(name string) string {
+	if merged, ok := b.rootNames[name]; ok {
+		return merged
+	}
+	return name
+}
+
+// existingNames returns the names of the types that are copied.
+func (b *schemaBuilder) existingNames() []string {
+	names := make([]string, 0, len(b.existing))
+	for name := range b.existing {
+		names = append(names, name)
+	}
+	return names
+}
+
+// addDefinitions adds the type definitions and extensions of the document to
+// the builder, and returns its schema and directive definitions.
+func (b *schemaBuilder) addDefinitions(doc *ast.Document) (*ast.SchemaDefinition, []*ast.DirectiveDefinition, error) {
+	var schemaDef *ast.SchemaDefinition
+	var extensions []*ast.TypeExtensionDefinition
+	var directiveDefs []*ast.DirectiveDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			if schemaDef != nil {
+				return nil, nil, gqlerrors.NewFormattedError("Must provide only one schema definition.")
+			}
+			schemaDef = def
+		case *ast.TypeExtensionDefinition:
+			extensions = append(extensions, def)
+		case *ast.DirectiveDefinition:
+			directiveDefs = append(directiveDefs, def)
+		case ast.TypeDefinition:
+			name := typeDefinitionName(def)
+			if b.existingType(name) != nil {
+				return nil, nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Type "%s" already exists in the schema. It cannot also be defined in this type definition.`, name))
+			}
+			if _, ok := b.defs[name]; ok || builtinScalars[name] != nil {
+				return nil, nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Type "%s" was defined more than once.`, name))
+			}
+			b.defs[name] = def
+		default:
+			return nil, nil, gqlerrors.NewFormattedError("Schema documents may only contain type system definitions.")
+		}
+	}
+	for _, ext := range extensions {
+		name := typeDefinitionName(ext.ExtendedType())
+		var kind string
+		if def, ok := b.defs[name]; ok {
+			kind = typeDefinitionKind(def)
+		} else if ttype := b.existingType(name); ttype != nil {
+			kind = typeKind(ttype)
+		} else {
+			return nil, nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Cannot extend type "%s" because it does not exist.`, name))
+		}
+		if extKind := typeDefinitionKind(ext.ExtendedType()); kind != extKind {
+			return nil, nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Cannot extend %s "%s" with an "extend %s".`, kind, name, extKind))
+		}
+		b.extensions[name] = append(b.extensions[name], ext.ExtendedType())
+	}
+	return schemaDef, directiveDefs, nil
+}
+
+// existingType returns the first of the copied types with the given name.
+func (b *schemaBuilder) existingType(name string) Type {
+	if existing := b.existing[name]; len(existing) != 0 {
+		return existing[0]
+	}
+	return nil
+}
+
+// buildTypes builds the named types, in sorted order, and adds their fields.
+func (b *schemaBuilder) buildTypes(names []string) ([]Type, error) {
+	sort.Strings(names)
+	types := make([]Type, 0, len(names))
+	for _, name := range names {
+		ttype, err := b.buildType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, ttype)
+	}
+	if err := b.buildFields(); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func (b *schemaBuilder) buildType(name string) (Type, error) {
+	if ttype, ok := b.types[name]; ok {
+		return ttype, nil
+	}
+	if ttype, ok := builtinScalars[name]; ok {
+		return ttype, nil
+	}
+	def, ok := b.defs[name]
+	if !ok {
+		if existing := b.existing[name]; len(existing) != 0 {
+			return b.copyType(name, existing)
+		}
+		return nil, gqlerrors.NewFormattedError(unknownTypeMessage(name, suggestionList(name, b.typeNames())))
+	}
+
+	var ttype Type
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		if scalar, ok := b.scalars[name]; ok {
+			if scalar.Name() != name {
+				return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Scalar "%s" was provided for type "%s".`, scalar.Name(), name))
+			}
+			ttype = scalar
+			break
+		}
+		ttype = NewScalar(ScalarConfig{
+			Name:           name,
+			Description:    description(def.Description, nil),
+			Serialize:      func(value interface{}) interface{} { return value },
+			ParseValue:     func(value interface{}) interface{} { return value },
+			ParseLiteral:   literalValue,
+			SpecifiedByURL: specifiedByURL(def.Directives),
+		})
+	case *ast.EnumDefinition:
+		values := EnumValueConfigMap{}
+		if err := addEnumValues(name, values, append([]ast.TypeDefinition{def}, b.extensions[name]...)); err != nil {
+			return nil, err
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: description(def.Description, def.Doc),
+			Values:      values,
+		})
+	case *ast.ObjectDefinition:
+		interfaces, err := b.buildInterfaces(name, append([]ast.TypeDefinition{def}, b.extensions[name]...))
+		if err != nil {
+			return nil, err
+		}
+		ttype = NewObject(ObjectConfig{
+			Name:        name,
+			Description: description(def.Description, def.Doc),
+			Interfaces:  interfaces,
+			Fields:      Fields{},
+		})
+		b.pending = append(b.pending, name)
+	case *ast.InterfaceDefinition:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: description(def.Description, def.Doc),
+			Interfaces: InterfacesThunk(func() []*Interface {
+				return b.interfaces[name]
+			}),
+			Fields:          Fields{},
+			ResolveTypeName: typenameOf,
+		})
+		b.pending = append(b.pending, name)
+	case *ast.UnionDefinition:
+		members, err := b.buildMembers(name, append([]ast.TypeDefinition{def}, b.extensions[name]...))
+		if err != nil {
+			return nil, err
+		}
+		ttype = NewUnion(UnionConfig{
+			Name:            name,
+			Description:     description(def.Description, def.Doc),
+			Types:           members,
+			ResolveTypeName: typenameOf,
+		})
+	case *ast.InputObjectDefinition:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: description(def.Description, def.Doc),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				return b.inputFields[name]
+			}),
+			IsOneOf: isOneOf(append([]ast.TypeDefinition{def}, b.extensions[name]...)),
+		})
+		b.pending = append(b.pending, name)
+	}
+	if err := ttype.Error(); err != nil {
+		return nil, err
+	}
+	b.types[name] = ttype
+	return ttype, nil
+}
+
+// copyType returns a copy of the types of the extended or merged schemas
+// with the given name, with the extensions of the document applied. The types
+// must be of the same kind, and their members (enum values, interfaces, union
+// member types) are combined. Scalars must have the same specification URL, and
+// enum values of the same name must have the same value. The fields of
+// objects, interfaces, and input objects are copied by buildFields.
+func (b *schemaBuilder) copyType(name string, existing []Type) (Type, error) {
+	for _, other := range existing[1:] {
+		if kind, otherKind := typeKind(existing[0]), typeKind(other); kind != otherKind {
+			return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Cannot merge %s "%s" with %s "%s".`, kind, name, otherKind, name))
+		}
+	}
+	extensions := b.extensions[name]
+	var ttype Type
+	switch first := existing[0].(type) {
+	case *Scalar:
+		for _, other := range existing[1:] {
+			if url, otherURL := first.SpecifiedByURL(), other.(*Scalar).SpecifiedByURL(); url != otherURL {
+				return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Scalar "%s" has conflicting specification URLs "%s" and "%s" in the merged schemas.`, name, url, otherURL))
+			}
+		}
+		ttype = first
+	case *Enum:
+		if len(existing) == 1 && len(extensions) == 0 {
+			ttype = first
+			break
+		}
+		values := EnumValueConfigMap{}
+		for _, ttype := range existing {
+			for _, v := range ttype.(*Enum).Values() {
+				if other, ok := values[v.Name]; ok {
+					if !reflect.DeepEqual(other.Value, v.Value) {
+						return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Enum value "%s.%s" has conflicting values %v and %v in the merged schemas.`, name, v.Name, other.Value, v.Value))
+					}
+					continue
+				}
+				values[v.Name] = &EnumValueConfig{
+					Value:             v.Value,
+					Description:       v.Description,
+					DeprecationReason: v.DeprecationReason,
+				}
+			}
+		}
+		if err := addEnumValues(name, values, extensions); err != nil {
+			return nil, err
+		}
+		config := first.enumConfig
+		config.Values = values
+		ttype = NewEnum(config)
+	case *Object:
+		var interfaces []*Interface
+		for _, ttype := range existing {
+			copies, err := b.copyInterfaces(ttype.(*Object).Interfaces())
+			if err != nil {
+				return nil, err
+			}
+			interfaces = appendInterfaces(interfaces, copies...)
+		}
+		extInterfaces, err := b.buildInterfaces(name, extensions)
+		if err != nil {
+			return nil, err
+		}
+		first.mu.RLock()
+		config := first.typeConfig
+		first.mu.RUnlock()
+		config.Interfaces = appendInterfaces(interfaces, extInterfaces...)
+		config.Fields = Fields{}
+		ttype = NewObject(config)
+		b.copied[name] = existing
+		b.pending = append(b.pending, name)
+	case *Interface:
+		first.mu.RLock()
+		config := first.typeConfig
+		first.mu.RUnlock()
+		config.Interfaces = InterfacesThunk(func() []*Interface {
+			return b.interfaces[name]
+		})
+		config.Fields = Fields{}
+		var resolveTypes []ResolveTypeFn
+		var resolveTypeNames []ResolveTypeNameFn
+		for _, ttype := range existing {
+			resolveTypes = append(resolveTypes, ttype.(*Interface).ResolveType)
+			resolveTypeNames = append(resolveTypeNames, ttype.(*Interface).ResolveTypeName)
+		}
+		config.ResolveType = chainResolveType(resolveTypes)
+		config.ResolveTypeName = chainResolveTypeName(resolveTypeNames)
+		ttype = NewInterface(config)
+		b.copied[name] = existing
+		b.pending = append(b.pending, name)
+	case *Union:
+		var members []*Object
+		seen := make(map[string]bool)
+		for _, ttype := range existing {
+			for _, member := range ttype.(*Union).Types() {
+				memberName := b.mergedName(member.Name())
+				if seen[memberName] {
+					continue
+				}
+				seen[memberName] = true
+				obj, err := b.buildType(memberName)
+				if err != nil {
+					return nil, err
+				}
+				members = append(members, obj.(*Object))
+			}
+		}
+		extMembers, err := b.buildMembers(name, extensions)
+		if err != nil {
+			return nil, err
+		}
+		config := first.typeConfig
+		config.Types = append(members, extMembers...)
+		var resolveTypes []ResolveTypeFn
+		var resolveTypeNames []ResolveTypeNameFn
+		for _, ttype := range existing {
+			resolveTypes = append(resolveTypes, ttype.(*Union).ResolveType)
+			resolveTypeNames = append(resolveTypeNames, ttype.(*Union).ResolveTypeName)
+		}
+		config.ResolveType = chainResolveType(resolveTypes)
+		config.ResolveTypeName = chainResolveTypeName(resolveTypeNames)
+		ttype = NewUnion(config)
+	case *InputObject:
+		config := first.typeConfig
+		config.Fields = InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			return b.inputFields[name]
+		})
+		for _, ttype := range existing {
+			config.IsOneOf = config.IsOneOf || ttype.(*InputObject).IsOneOf()
+		}
+		config.IsOneOf = config.IsOneOf || isOneOf(extensions)
+		ttype = NewInputObject(config)
+		b.copied[name] = existing
+		b.pending = append(b.pending, name)
+	default:
+		return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Cannot copy type "%s" of unknown kind %T.`, name, first))
+	}
+	if err := ttype.Error(); err != nil {
+		return nil, err
+	}
+	b.types[name] = ttype
+	return ttype, nil
+}
+
+// copyFields adds the fields of the copied types to the pending type with the
+// given name, and marks their names as seen. A field that's defined by more
+// than one of the types must have the same type and arguments in each of them,
+// and the first definition is used.
+func (b *schemaBuilder) copyFields(name string, seen map[string]bool) error {
+	signatures := make(map[string]string)
+	for _, existing := range b.copied[name] {
+		switch existing := existing.(type) {
+		case *Object:
+			fields, err := b.copyFieldDefinitions(name, existing.Fields(), signatures)
+			if err != nil {
+				return err
+			}
+			for fieldName, field := range fields {
+				b.types[name].(*Object).AddFieldConfig(fieldName, field)
+			}
+		case *Interface:
+			fields, err := b.copyFieldDefinitions(name, existing.Fields(), signatures)
+			if err != nil {
+				return err
+			}
+			for fieldName, field := range fields {
+				b.types[name].(*Interface).AddFieldConfig(fieldName, field)
+			}
+			copies, err := b.copyInterfaces(existing.Interfaces())
+			if err != nil {
+				return err
+			}
+			b.interfaces[name] = appendInterfaces(b.interfaces[name], copies...)
+		case *InputObject:
+			if b.inputFields[name] == nil {
+				b.inputFields[name] = InputObjectConfigFieldMap{}
+			}
+			for _, fieldName := range sortedInputFieldNames(existing.Fields()) {
+				field := existing.Fields()[fieldName]
+				if err := checkSignature(name, fieldName, field.Type.String(), signatures); err != nil {
+					return err
+				} else if _, ok := b.inputFields[name][fieldName]; ok {
+					continue
+				}
+				ttype, err := b.copyTypeRef(field.Type)
+				if err != nil {
+					return err
+				}
+				b.inputFields[name][fieldName] = &InputObjectFieldConfig{
+					Type:         ttype.(Input),
+					DefaultValue: field.DefaultValue,
+					Description:  field.PrivateDescription,
+				}
+			}
+		}
+	}
+	for fieldName := range signatures {
+		seen[fieldName] = true
+	}
+	return nil
+}
+
+// copyFieldDefinitions returns the configs of copies of the fields that refer
+// to the types of the new schema, keeping their resolvers. Fields whose
+// signatures were already recorded aren't copied again.
+func (b *schemaBuilder) copyFieldDefinitions(typeName string, defs FieldDefinitionMap, signatures map[string]string) (Fields, error) {
+	fields := make(Fields, len(defs))
+	for _, fieldName := range sortedFieldNames(defs) {
+		fd := defs[fieldName]
+		_, copied := signatures[fieldName]
+		if err := checkSignature(typeName, fieldName, fieldSignature(fd), signatures); err != nil {
+			return nil, err
+		} else if copied {
+			continue
+		}
+		ttype, err := b.copyTypeRef(fd.Type)
+		if err != nil {
+			return nil, err
+		}
+		args := make(FieldConfigArgument, len(fd.Args))
+		for _, arg := range fd.Args {
+			argType, err := b.copyTypeRef(arg.Type)
+			if err != nil {
+				return nil, err
+			}
+			args[arg.PrivateName] = &ArgumentConfig{
+				Type:         argType.(Input),
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.PrivateDescription,
+			}
+		}
+		fields[fieldName] = &Field{
+			Type:              ttype.(Output),
+			Args:              args,
+			Resolve:           fd.Resolve,
+			Complexity:        fd.Complexity,
+			DeprecationReason: fd.DeprecationReason,
+			Description:       fd.Description,
+		}
+	}
+	return fields, nil
+}
+
+// checkSignature records the signature of a copied field, and returns an error
+// if a field of the same name was copied with a different signature.
+func checkSignature(typeName, fieldName, signature string, signatures map[string]string) error {
+	if other, ok := signatures[fieldName]; ok && other != signature {
+		return gqlerrors.NewFormattedError(fmt.Sprintf(`Field "%s.%s" has conflicting definitions "%s" and "%s" in the merged schemas.`, typeName, fieldName, other, signature))
+	}
+	signatures[fieldName] = signature
+	return nil
+}
+
+// fieldSignature returns the type of a field preceded by its arguments, if any
+// (e.g. "(first: Int): [User]").
+func fieldSignature(fd *FieldDefinition) string {
+	if len(fd.Args) == 0 {
+		return fd.Type.String()
+	}
+	args := make([]string, len(fd.Args))
+	for i, arg := range fd.Args {
+		args[i] = arg.PrivateName + ": " + arg.Type.String()
+	}
+	return "(" + strings.Join(args, ", ") + "): " + fd.Type.String()
+}
+
+// chainResolveType returns a function that returns the first type resolved by
+// the functions of the types that were merged, so that values are resolved by
+// the schema that defines their type. It returns nil if none of them are set.
+func chainResolveType(fns []ResolveTypeFn) ResolveTypeFn {
+	var set []ResolveTypeFn
+	for _, fn := range fns {
+		if fn != nil {
+			set = append(set, fn)
+		}
+	}
+	if len(set) <= 1 {
+		if len(set) == 0 {
+			return nil
+		}
+		return set[0]
+	}
+	return func(p ResolveTypeParams) *Object {
+		for _, fn := range set {
+			if obj := fn(p); obj != nil {
+				return obj
+			}
+		}
+		return nil
+	}
+}
+
+// chainResolveTypeName is like chainResolveType for ResolveTypeNameFn.
+func chainResolveTypeName(fns []ResolveTypeNameFn) ResolveTypeNameFn {
+	var set []ResolveTypeNameFn
+	for _, fn := range fns {
+		if fn != nil {
+			set = append(set, fn)
+		}
+	}
+	if len(set) <= 1 {
+		if len(set) == 0 {
+			return nil
+		}
+		return set[0]
+	}
+	return func(p ResolveTypeParams) string {
+		for _, fn := range set {
+			if name := fn(p); name != "" {
+				return name
+			}
+		}
+		return ""
+	}
+}
+
+// copyInterfaces returns the copies of the interfaces in the new schema.
+func (b *schemaBuilder) copyInterfaces(interfaces []*Interface) ([]*Interface, error) {
+	copies := make([]*Interface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		it, err := b.buildType(b.mergedName(iface.Name()))
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, it.(*Interface))
+	}
+	return copies, nil
+}
+
+// appendInterfaces appends the interfaces that aren't in the list already.
+func appendInterfaces(interfaces []*Interface, more ...*Interface) []*Interface {
+next:
+	for _, iface := range more {
+		for _, it := range interfaces {
+			if it.Name() == iface.Name() {
+				continue next
+			}
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
+// copyTypeRef returns the type of the new schema that corresponds to a type
+// of the extended schema, wrapped in the same lists and non-nulls.
+func (b *schemaBuilder) copyTypeRef(ttype Type) (Type, error) {
+	switch ttype := ttype.(type) {
+	case *List:
+		ofType, err := b.copyTypeRef(ttype.OfType)
+		if err != nil {
+			return nil, err
+		}
+		return NewList(ofType), nil
+	case *NonNull:
+		ofType, err := b.copyTypeRef(ttype.OfType)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(ofType), nil
+	}
+	return b.buildType(b.mergedName(ttype.Name()))
+}
+
+// addEnumValues adds the values of the enum definitions to values.
+func addEnumValues(name string, values EnumValueConfigMap, defs []ast.TypeDefinition) error {
+	for _, d := range defs {
+		for _, v := range d.(*ast.EnumDefinition).Values {
+			if _, ok := values[v.Name.Value]; ok {
+				return gqlerrors.NewFormattedError(fmt.Sprintf(`Enum value "%s.%s" already exists in the schema. It cannot also be defined in this type extension.`, name, v.Name.Value))
+			}
+			values[v.Name.Value] = &EnumValueConfig{
+				Description:       description(v.Description, v.Doc),
+				DeprecationReason: deprecationReason(v.Directives),
+			}
+		}
+	}
+	return nil
+}
+
+// buildInterfaces returns the interfaces implemented by the object definitions.
+func (b *schemaBuilder) buildInterfaces(name string, defs []ast.TypeDefinition) ([]*Interface, error) {
+	var interfaces []*Interface
+	for _, d := range defs {
+		for _, named := range d.(*ast.ObjectDefinition).Interfaces {
+			iface, err := b.buildType(named.Name.Value)
+			if err != nil {
+				return nil, err
+			}
+			it, ok := iface.(*Interface)
+			if !ok {
+				return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Type "%s" cannot implement non-interface type "%s".`, name, named.Name.Value))
+			}
+			interfaces = append(interfaces, it)
+		}
+	}
+	return interfaces, nil
+}
+
+// buildMembers returns the member types of the union definitions.
+func (b *schemaBuilder) buildMembers(name string, defs []ast.TypeDefinition) ([]*Object, error) {
+	var members []*Object
+	for _, d := range defs {
+		for _, named := range d.(*ast.UnionDefinition).Types {
+			member, err := b.buildType(named.Name.Value)
+			if err != nil {
+				return nil, err
+			}
+			obj, ok := member.(*Object)
+			if !ok {
+				return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Union "%s" may only contain object types, it cannot contain "%s".`, name, named.Name.Value))
+			}
+			members = append(members, obj)
+		}
+	}
+	return members, nil
+}
+
+// isOneOf returns true if any of the input object definitions has the @oneOf
+// directive.
+func isOneOf(defs []ast.TypeDefinition) bool {
+	for _, d := range defs {
+		if hasDirective(d.(*ast.InputObjectDefinition).Directives, "oneOf") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeNames returns the names of the types that may be referred to.
+func (b *schemaBuilder) typeNames() []string {
+	names := make([]string, 0, len(builtinScalars)+len(b.defs))
+	for name := range builtinScalars {
+		names = append(names, name)
+	}
+	for name := range b.defs {
+		names = append(names, name)
+	}
+	for name := range b.existing {
+		if builtinScalars[name] == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// buildFields adds the fields of the base definition and all extensions to
+// each of the pending object, interface, and input object types.
+func (b *schemaBuilder) buildFields() error {
+	for len(b.pending) != 0 {
+		name := b.pending[0]
+		b.pending = b.pending[1:]
+		seen := make(map[string]bool)
+		if err := b.copyFields(name, seen); err != nil {
+			return err
+		}
+		for _, def := range append([]ast.TypeDefinition{b.defs[name]}, b.extensions[name]...) {
+			var fieldDefs []*ast.FieldDefinition
+			switch def := def.(type) {
+			case *ast.ObjectDefinition:
+				fieldDefs = def.Fields
+			case *ast.InterfaceDefinition:
+				fieldDefs = def.Fields
+				for _, named := range def.Interfaces {
+					iface, err := b.buildType(named.Name.Value)
+					if err != nil {
+						return err
+					}
+					it, ok := iface.(*Interface)
+					if !ok {
+						return gqlerrors.NewFormattedError(fmt.Sprintf(`Type "%s" cannot implement non-interface type "%s".`, name, named.Name.Value))
+					}
+					b.interfaces[name] = append(b.interfaces[name], it)
+				}
+			case *ast.InputObjectDefinition:
+				for _, fd := range def.Fields {
+					if seen[fd.Name.Value] {
+						return fieldConflictError(name, fd.Name.Value)
+					}
+					seen[fd.Name.Value] = true
+					ttype, err := b.inputType(fd.Type)
+					if err != nil {
+						return err
+					}
+					if b.inputFields[name] == nil {
+						b.inputFields[name] = InputObjectConfigFieldMap{}
+					}
+					b.inputFields[name][fd.Name.Value] = &InputObjectFieldConfig{
+						Type:         ttype,
+						DefaultValue: valueFromAST(fd.DefaultValue, ttype, nil),
+						Description:  description(fd.Description, fd.Doc),
+					}
+				}
+			}
+			for _, fd := range fieldDefs {
+				if seen[fd.Name.Value] {
+					return fieldConflictError(name, fd.Name.Value)
+				}
+				seen[fd.Name.Value] = true
+				field, err := b.buildField(fd)
+				if err != nil {
+					return err
+				}
+				switch ttype := b.types[name].(type) {
+				case *Object:
+					ttype.AddFieldConfig(fd.Name.Value, field)
+				case *Interface:
+					ttype.AddFieldConfig(fd.Name.Value, field)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// buildDirectives returns the directives defined in the document.
+func (b *schemaBuilder) buildDirectives(defs []*ast.DirectiveDefinition) ([]*Directive, error) {
+	defined := make(map[string]*Directive, len(defs))
+	custom := make([]*Directive, 0, len(defs))
+	for _, def := range defs {
+		name := def.Name.Value
+		if _, ok := defined[name]; ok {
+			return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Directive "@%s" was defined more than once.`, name))
+		}
+		args := FieldConfigArgument{}
+		for _, ad := range def.Arguments {
+			argType, err := b.inputType(ad.Type)
+			if err != nil {
+				return nil, err
+			}
+			args[ad.Name.Value] = &ArgumentConfig{
+				Type:         argType,
+				DefaultValue: valueFromAST(ad.DefaultValue, argType, nil),
+				Description:  description(ad.Description, ad.Doc),
+			}
+		}
+		locations := make([]string, len(def.Locations))
+		for i, loc := range def.Locations {
+			locations[i] = loc.Value
+		}
+		dir := NewDirective(DirectiveConfig{
+			Name:        name,
+			Description: description(def.Description, def.Doc),
+			Locations:   locations,
+			Args:        args,
+		})
+		if dir.err != nil {
+			return nil, dir.err
+		}
+		defined[name] = dir
+		custom = append(custom, dir)
+	}
+	return custom, nil
+}
+
+func fieldConflictError(typeName, fieldName string) error {
+	return gqlerrors.NewFormattedError(fmt.Sprintf(`Field "%s.%s" already exists in the schema. It cannot also be defined in this type extension.`, typeName, fieldName))
+}
+
+func (b *schemaBuilder) buildField(fd *ast.FieldDefinition) (*Field, error) {
+	ttype, err := b.typeFromAST(fd.Type)
+	if err != nil {
+		return nil, err
+	}
+	output, ok := ttype.(Output)
+	if !ok || !IsOutputType(ttype) {
+		return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Field "%s" must be an output type but got "%s".`, fd.Name.Value, ttype))
+	}
+	args := FieldConfigArgument{}
+	for _, ad := range fd.Arguments {
+		argType, err := b.inputType(ad.Type)
+		if err != nil {
+			return nil, err
+		}
+		args[ad.Name.Value] = &ArgumentConfig{
+			Type:         argType,
+			DefaultValue: valueFromAST(ad.DefaultValue, argType, nil),
+			Description:  description(ad.Description, ad.Doc),
+		}
+	}
+	return &Field{
+		Type:              output,
+		Args:              args,
+		Description:       description(fd.Description, fd.Doc),
+		DeprecationReason: deprecationReason(fd.Directives),
+	}, nil
+}
+
+func (b *schemaBuilder) inputType(typeAST ast.Type) (Input, error) {
+	ttype, err := b.typeFromAST(typeAST)
+	if err != nil {
+		return nil, err
+	}
+	input, ok := ttype.(Input)
+	if !ok || !IsInputType(ttype) {
+		return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Expected input type but got "%s".`, ttype))
+	}
+	return input, nil
+}
+
+func (b *schemaBuilder) typeFromAST(typeAST ast.Type) (Type, error) {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		ttype, err := b.typeFromAST(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewList(ttype), nil
+	case *ast.NonNull:
+		ttype, err := b.typeFromAST(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(ttype), nil
+	case *ast.Named:
+		return b.buildType(typeAST.Name.Value)
+	}
+	return nil, gqlerrors.NewFormattedError(fmt.Sprintf("Unknown type AST %T.", typeAST))
+}
+
+// typenameOf resolves the concrete type of a value of an abstract type from
+// its `__typename` key.
+func typenameOf(p ResolveTypeParams) string {
+	switch v := p.Value.(type) {
+	case map[string]interface{}:
+		name, _ := v["__typename"].(string)
+		return name
+	case map[string]string:
+		return v["__typename"]
+	}
+	return ""
+}
+
+func typeDefinitionName(def ast.TypeDefinition) string {
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		return def.Name.Value
+	case *ast.ObjectDefinition:
+		return def.Name.Value
+	case *ast.InterfaceDefinition:
+		return def.Name.Value
+	case *ast.UnionDefinition:
+		return def.Name.Value
+	case *ast.EnumDefinition:
+		return def.Name.Value
+	case *ast.InputObjectDefinition:
+		return def.Name.Value
+	}
+	return ""
+}
+
+// typeDefinitionKind returns the keyword used to define the type.
+func typeDefinitionKind(def ast.TypeDefinition) string {
+	switch def.(type) {
+	case *ast.ScalarDefinition:
+		return "scalar"
+	case *ast.ObjectDefinition:
+		return "type"
+	case *ast.InterfaceDefinition:
+		return "interface"
+	case *ast.UnionDefinition:
+		return "union"
+	case *ast.EnumDefinition:
+		return "enum"
+	case *ast.InputObjectDefinition:
+		return "input"
+	}
+	return ""
+}
+
+// typeKind returns the keyword used to define a type like the given one.
+func typeKind(ttype Type) string {
+	switch ttype.(type) {
+	case *Scalar:
+		return "scalar"
+	case *Object:
+		return "type"
+	case *Interface:
+		return "interface"
+	case *Union:
+		return "union"
+	case *Enum:
+		return "enum"
+	case *InputObject:
+		return "input"
+	}
+	return ""
+}
+
+// description returns the text of a definition's description, falling back to
+// its doc comment for schemas written before descriptions replaced comments.
+func description(desc *ast.StringValue, doc *ast.CommentGroup) string {
+	if desc != nil {
+		return desc.Value
+	}
+	return commentDescription(doc)
+}
+
+// commentDescription returns the text of a comment group with the leading
+// comment markers removed.
+func commentDescription(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	lines := make([]string, len(cg.List))
+	for i, c := range cg.List {
+		lines[i] = strings.TrimLeft(c.Text, "# ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasDirective returns true if the directive with the given name is applied.
+func hasDirective(directives []*ast.Directive, name string) bool {
+	for _, d := range directives {
+		if d.Name != nil && d.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
+func deprecationReason(directives []*ast.Directive) string {
+	for _, d := range directives {
+		if d.Name == nil || d.Name.Value != DeprecatedDirective.Name {
+			continue
+		}
+		for _, arg := range d.Arguments {
+			if arg.Name != nil && arg.Name.Value == "reason" {
+				if reason, ok := arg.Value.(*ast.StringValue); ok {
+					return reason.Value
+				}
+			}
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// specifiedByURL returns the url argument of a @specifiedBy directive.
+func specifiedByURL(directives []*ast.Directive) string {
+	for _, d := range directives {
+		if d.Name == nil || d.Name.Value != SpecifiedByDirective.Name {
+			continue
+		}
+		for _, arg := range d.Arguments {
+			if arg.Name != nil && arg.Name.Value == "url" {
+				if url, ok := arg.Value.(*ast.StringValue); ok {
+					return url.Value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// literalValue converts a literal to its plain Go representation. It's used to
+// parse literals of custom scalars defined in a schema document.
+func literalValue(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+			return int(i)
+		}
+		return nil
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return f
+		}
+		return nil
+	case *ast.ListValue:
+		values := make([]interface{}, len(valueAST.Values))
+		for i, v := range valueAST.Values {
+			values[i] = literalValue(v)
+		}
+		return values
+	case *ast.ObjectValue:
+		fields := make(map[string]interface{}, len(valueAST.Fields))
+		for _, f := range valueAST.Fields {
+			fields[f.Name.Value] = literalValue(f.Value)
+		}
+		return fields
+	}
+	return nil
+}
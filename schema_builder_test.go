@@ -0,0 +1,637 @@
+package graphql_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestBuildSchema_MergesTypeExtensions(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  # Says hello
+  hello: String
+}
+
+interface Named {
+  name: String
+}
+
+type Dog implements Named {
+  name: String
+}
+
+type Cat {
+  name: String
+}
+
+union Pet = Dog
+
+enum Color { RED }
+
+input Filter {
+  color: Color
+}
+
+extend type Query {
+  pets(filter: Filter): [Pet]
+  named: [Named]
+}
+
+extend interface Named {
+  nickname: String
+}
+
+extend type Dog {
+  nickname: String
+}
+
+extend type Cat implements Named {
+  nickname: String
+}
+
+extend union Pet = Cat
+
+extend enum Color { GREEN }
+
+extend input Filter {
+  limit: Int = 10
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if desc := schema.QueryType().Fields()["hello"].Description; desc != "Says hello" {
+		t.Fatalf("Expected field description %q, got %q", "Says hello", desc)
+	}
+	var fields []string
+	for name := range schema.QueryType().Fields() {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	if expected := []string{"hello", "named", "pets"}; !reflect.DeepEqual(expected, fields) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, fields))
+	}
+	var members []string
+	for _, obj := range schema.Type("Pet").(*graphql.Union).Types() {
+		members = append(members, obj.Name())
+	}
+	if expected := []string{"Dog", "Cat"}; !reflect.DeepEqual(expected, members) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, members))
+	}
+	if len(schema.Type("Color").(*graphql.Enum).Values()) != 2 {
+		t.Fatalf("Expected enum Color to have 2 values")
+	}
+	limit := schema.Type("Filter").(*graphql.InputObject).Fields()["limit"]
+	if limit == nil || limit.DefaultValue != 10 {
+		t.Fatalf("Expected input field Filter.limit with default 10, got %+v", limit)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			hello
+			pets { ... on Dog { name } ... on Cat { nickname } }
+			named { name nickname }
+		}`,
+		RootObject: map[string]interface{}{
+			"hello": "world",
+			"pets": []interface{}{
+				map[string]interface{}{"__typename": "Dog", "name": "Rex"},
+				map[string]interface{}{"__typename": "Cat", "nickname": "Kitty"},
+			},
+			"named": []interface{}{
+				map[string]interface{}{"__typename": "Cat", "name": "Tom", "nickname": "T"},
+			},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hello": "world",
+			"pets": []interface{}{
+				map[string]interface{}{"name": "Rex"},
+				map[string]interface{}{"nickname": "Kitty"},
+			},
+			"named": []interface{}{
+				map[string]interface{}{"name": "Tom", "nickname": "T"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildSchema_RejectsInvalidTypeExtensions(t *testing.T) {
+	tests := []struct {
+		sdl string
+		err string
+	}{
+		{
+			sdl: `
+type Query { hello: String }
+extend type Query { hello: String }`,
+			err: `Field "Query.hello" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		{
+			sdl: `
+type Query { hello: String }
+input Filter { limit: Int }
+extend input Filter { offset: Int }
+extend input Filter { offset: Int }`,
+			err: `Field "Filter.offset" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		{
+			sdl: `
+type Query { hello: String }
+enum Color { RED }
+extend enum Color { RED }`,
+			err: `Enum value "Color.RED" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		{
+			sdl: `
+type Query { hello: String }
+extend type Unknown { hello: String }`,
+			err: `Cannot extend type "Unknown" because it does not exist.`,
+		},
+		{
+			sdl: `
+type Query { hello: String }
+extend interface Query { world: String }`,
+			err: `Cannot extend type "Query" with an "extend interface".`,
+		},
+	}
+	for _, test := range tests {
+		_, err := graphql.BuildSchema(test.sdl)
+		if err == nil {
+			t.Fatalf("Expected error %q building %s", test.err, test.sdl)
+		}
+		if err.Error() != test.err {
+			t.Fatalf("Expected error %q, got %q", test.err, err.Error())
+		}
+	}
+}
+
+func TestBuildSchema_RegistersDirectiveDefinitions(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  secret: String @auth(role: "admin")
+}
+
+# Restricts access to a field
+directive @auth(role: String!) on FIELD_DEFINITION | OBJECT
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := schema.Directive("auth")
+	if dir == nil {
+		t.Fatal("Expected @auth to be registered")
+	}
+	if dir.Description != "Restricts access to a field" {
+		t.Fatalf("Unexpected description %q", dir.Description)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			__schema {
+				directives {
+					name
+					locations
+					args { name type { kind ofType { name } } }
+				}
+			}
+		}`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	var names []string
+	var auth interface{}
+	for _, d := range result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["directives"].([]interface{}) {
+		d := d.(map[string]interface{})
+		names = append(names, d["name"].(string))
+		if d["name"] == "auth" {
+			auth = d
+		}
+	}
+	if expected := []string{"include", "skip", "deprecated", "specifiedBy", "auth"}; !reflect.DeepEqual(expected, names) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, names))
+	}
+	expected := map[string]interface{}{
+		"name":      "auth",
+		"locations": []interface{}{"FIELD_DEFINITION", "OBJECT"},
+		"args": []interface{}{
+			map[string]interface{}{
+				"name": "role",
+				"type": map[string]interface{}{
+					"kind":   "NON_NULL",
+					"ofType": map[string]interface{}{"name": "String"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, auth) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, auth))
+	}
+}
+
+func TestBuildSchema_ParsesArgumentDefaultsWithCustomScalars(t *testing.T) {
+	dateTime := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "DateTime",
+		Serialize: func(value interface{}) interface{} {
+			if t, ok := value.(time.Time); ok {
+				return t.Format(time.RFC3339)
+			}
+			return nil
+		},
+		ParseValue: func(value interface{}) interface{} {
+			if s, ok := value.(string); ok {
+				if t, err := time.Parse(time.RFC3339, s); err == nil {
+					return t
+				}
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if v, ok := valueAST.(*ast.StringValue); ok {
+				if t, err := time.Parse(time.RFC3339, v.Value); err == nil {
+					return t
+				}
+			}
+			return nil
+		},
+	})
+	schema, err := graphql.BuildSchemaWithOptions(`
+scalar DateTime
+
+type Query {
+  events(createdAfter: DateTime = "2020-01-01T00:00:00Z"): String
+}
+`, graphql.BuildOptions{Scalars: map[string]*graphql.Scalar{"DateTime": dateTime}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var createdAfter interface{}
+	schema.QueryType().Fields()["events"].Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		createdAfter = p.Args["createdAfter"]
+		return "ok", nil
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ events }`,
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if expected := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC); !reflect.DeepEqual(expected, createdAfter) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, createdAfter))
+	}
+}
+
+func TestBuildSchema_UsesDescriptions(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+"""
+The root of all queries.
+"""
+type Query {
+  "Looks up pets"
+  pets(
+    """Only pets matching the filter"""
+    filter: Filter
+  ): [Color]
+}
+
+"""
+Filters pets.
+
+  Indentation beyond the common indentation is kept.
+"""
+input Filter {
+  """The pet's color"""
+  color: Color
+}
+
+"A color"
+enum Color {
+  """Red"""
+  RED
+}
+
+"""A calendar date"""
+scalar Date
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			query: __type(name: "Query") {
+				description
+				fields { description args { description } }
+			}
+			filter: __type(name: "Filter") { description inputFields { description } }
+			color: __type(name: "Color") { description enumValues { description } }
+			date: __type(name: "Date") { description }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"query": map[string]interface{}{
+				"description": "The root of all queries.",
+				"fields": []interface{}{
+					map[string]interface{}{
+						"description": "Looks up pets",
+						"args": []interface{}{
+							map[string]interface{}{"description": "Only pets matching the filter"},
+						},
+					},
+				},
+			},
+			"filter": map[string]interface{}{
+				"description": "Filters pets.\n\n  Indentation beyond the common indentation is kept.",
+				"inputFields": []interface{}{
+					map[string]interface{}{"description": "The pet's color"},
+				},
+			},
+			"color": map[string]interface{}{
+				"description": "A color",
+				"enumValues": []interface{}{
+					map[string]interface{}{"description": "Red"},
+				},
+			},
+			"date": map[string]interface{}{
+				"description": "A calendar date",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildSchema_BuildsInterfacesImplementingInterfaces(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  entity: Entity
+}
+
+interface Node {
+  id: ID!
+}
+
+interface Entity implements Node {
+  id: ID!
+  name: String
+}
+
+type User implements Entity & Node {
+  id: ID!
+  name: String
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity, ok := schema.Type("Entity").(*graphql.Interface)
+	if !ok {
+		t.Fatalf("Expected Entity to be an interface, got %T", schema.Type("Entity"))
+	}
+	if interfaces := entity.Interfaces(); len(interfaces) != 1 || interfaces[0] != schema.Type("Node") {
+		t.Fatalf("Expected Entity to implement Node, got %v", interfaces)
+	}
+
+	_, err = graphql.BuildSchema(`
+type Query {
+  entity: Entity
+}
+
+interface Node {
+  id: ID!
+}
+
+interface Entity implements Node {
+  id: ID!
+}
+
+type User implements Entity {
+  id: ID!
+}
+`)
+	if err == nil || err.Error() != "Type User must implement Node because it is implemented by Entity." {
+		t.Fatalf("Expected the missing transitive interface to be rejected, got %v", err)
+	}
+}
+
+func TestBuildSchema_MarksOneOfInputObjects(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  user(by: UserBy!, filter: UserFilter): String
+}
+
+input UserBy @oneOf {
+  id: ID
+  email: String
+}
+
+input UserFilter {
+  name: String
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			userBy: __type(name: "UserBy") { isOneOf }
+			userFilter: __type(name: "UserFilter") { isOneOf }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"userBy":     map[string]interface{}{"isOneOf": true},
+			"userFilter": map[string]interface{}{"isOneOf": false},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildSchema_SuggestsUnknownTypes(t *testing.T) {
+	_, err := graphql.BuildSchema(`
+type Query {
+  pets(filter: Filtre): [Pet]
+}
+
+type Pet { name: String }
+input Filter { name: String }
+`)
+	expected := `Unknown type "Filtre". Did you mean "Filter"?`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+
+	_, err = graphql.BuildSchema(`type Query { name: Strnig }`)
+	expected = `Unknown type "Strnig". Did you mean "String"?`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestExtendSchema_AddsTypesFieldsAndDirectives(t *testing.T) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "world", nil
+					},
+				},
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{
+							"name":  "Ann",
+							"posts": []interface{}{map[string]interface{}{"title": "Hi"}},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extended, err := graphql.ExtendSchema(schema, `
+type Post {
+  title: String
+}
+
+extend type Query {
+  posts: [Post]
+}
+
+extend type User {
+  posts: [Post]
+}
+
+directive @cached on FIELD_DEFINITION
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        extended,
+		RequestString: `{ hello user { name posts { title } } posts { title } }`,
+		RootObject: map[string]interface{}{
+			"posts": []interface{}{map[string]interface{}{"title": "Welcome"}},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hello": "world",
+			"user": map[string]interface{}{
+				"name":  "Ann",
+				"posts": []interface{}{map[string]interface{}{"title": "Hi"}},
+			},
+			"posts": []interface{}{map[string]interface{}{"title": "Welcome"}},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if extended.Directive("cached") == nil || extended.Directive("deprecated") == nil {
+		t.Fatalf("Expected the extended schema to have the @cached and @deprecated directives")
+	}
+
+	// The original schema is unchanged.
+	if _, ok := schema.QueryType().Fields()["posts"]; ok {
+		t.Fatal("Expected the original Query type to not have a posts field")
+	}
+	if _, ok := userType.Fields()["posts"]; ok {
+		t.Fatal("Expected the original User type to not have a posts field")
+	}
+	if schema.Type("Post") != nil || schema.Directive("cached") != nil {
+		t.Fatal("Expected the original schema to not have the Post type or the @cached directive")
+	}
+}
+
+func TestExtendSchema_RejectsInvalidExtensions(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query { hello: String }
+enum Color { RED }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sdl string
+		err string
+	}{
+		{
+			sdl: `extend type Query { hello: String }`,
+			err: `Field "Query.hello" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		{
+			sdl: `extend enum Color { RED }`,
+			err: `Enum value "Color.RED" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		{
+			sdl: `extend type Unknown { hello: String }`,
+			err: `Cannot extend type "Unknown" because it does not exist.`,
+		},
+		{
+			sdl: `extend input Color { hello: String }`,
+			err: `Cannot extend enum "Color" with an "extend input".`,
+		},
+		{
+			sdl: `extend type Query { user: User }`,
+			err: `Unknown type "User".`,
+		},
+		{
+			sdl: `type Query { world: String }`,
+			err: `Type "Query" already exists in the schema. It cannot also be defined in this type definition.`,
+		},
+		{
+			sdl: `directive @skip on FIELD`,
+			err: `Directive "@skip" already exists in the schema. It cannot be redefined.`,
+		},
+		{
+			sdl: `schema { query: Query }`,
+			err: `Cannot define a new schema within a schema extension.`,
+		},
+	}
+	for _, test := range tests {
+		_, err := graphql.ExtendSchema(schema, test.sdl)
+		if err == nil {
+			t.Fatalf("Expected error %q extending with %s", test.err, test.sdl)
+		}
+		if err.Error() != test.err {
+			t.Fatalf("Expected error %q, got %q", test.err, err.Error())
+		}
+	}
+}
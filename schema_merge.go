@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sprucehealth/graphql/gqlerrors"
+)
+
+// MergeSchemas returns a schema that combines the types and directives of the
+// given schemas, such as the schemas of the services behind a gateway.
+//
+// Types of different schemas with the same name are merged into one type and
+// must be of the same kind. Their fields, enum values, interfaces, and union
+// member types are combined. The root types of each operation are merged into
+// the root type of the first schema that has one, so the root fields of all of
+// the schemas are available from the merged schema.
+//
+// Fields are copied with their resolvers so they're resolved by the schema
+// that defines them, and abstract types try the ResolveType functions of each
+// schema in turn. A field that's defined by more than one schema must have the
+// same type and arguments in each of them, and the first definition is used.
+// The same goes for directives, which must also have the same locations, for
+// enum values, which must have the same value, and for scalars, which must
+// have the same specification URL. The given schemas aren't modified. Schemas with a TypeLoader can't be merged.
+func MergeSchemas(schemas []Schema) (Schema, error) {
+	if len(schemas) == 0 {
+		return Schema{}, gqlerrors.NewFormattedError("Must provide at least one schema to merge.")
+	}
+	ptrs := make([]*Schema, len(schemas))
+	for i := range schemas {
+		if schemas[i].typeLoader != nil {
+			return Schema{}, gqlerrors.NewFormattedError("Cannot merge a schema that loads its types with a TypeLoader.")
+		}
+		ptrs[i] = &schemas[i]
+	}
+	b := newSchemaBuilder(nil, ptrs)
+	types, err := b.buildTypes(b.existingNames())
+	if err != nil {
+		return Schema{}, err
+	}
+
+	config := SchemaConfig{
+		Description: schemas[0].Description(),
+		Types:       types,
+	}
+	defined := make(map[string]string)
+	for _, schema := range schemas {
+		for _, dir := range schema.Directives() {
+			signature := directiveSignature(dir)
+			if other, ok := defined[dir.Name]; !ok {
+				defined[dir.Name] = signature
+				config.Directives = append(config.Directives, dir)
+			} else if other != signature {
+				return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Directive "@%s" has conflicting definitions "%s" and "%s" in the merged schemas.`, dir.Name, other, signature))
+			}
+		}
+		if root := schema.QueryType(); root != nil && config.Query == nil {
+			config.Query = b.types[root.Name()].(*Object)
+		}
+		if root := schema.MutationType(); root != nil && config.Mutation == nil {
+			config.Mutation = b.types[root.Name()].(*Object)
+		}
+		if root := schema.SubscriptionType(); root != nil && config.Subscription == nil {
+			config.Subscription = b.types[root.Name()].(*Object)
+		}
+	}
+	return NewSchema(config)
+}
+
+// directiveSignature returns the arguments of a directive, if any, followed by
+// its sorted locations (e.g. "(if: Boolean!) on FIELD | FRAGMENT_SPREAD").
+func directiveSignature(dir *Directive) string {
+	var signature string
+	if len(dir.Args) != 0 {
+		args := make([]string, len(dir.Args))
+		for i, arg := range dir.Args {
+			args[i] = arg.PrivateName + ": " + arg.Type.String()
+		}
+		signature = "(" + strings.Join(args, ", ") + ") "
+	}
+	locations := append([]string(nil), dir.Locations...)
+	sort.Strings(locations)
+	return signature + "on " + strings.Join(locations, " | ")
+}
@@ -0,0 +1,257 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+type mergeTestUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type mergeTestPost struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestMergeSchemas_MergesTypesAndRootFields(t *testing.T) {
+	var userType *graphql.Object
+	userNode := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(*mergeTestUser); ok {
+				return userType
+			}
+			return nil
+		},
+	})
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{userNode},
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	users, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &mergeTestUser{ID: "u1", Name: "Ann"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var postType *graphql.Object
+	postNode := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(*mergeTestPost); ok {
+				return postType
+			}
+			return nil
+		},
+	})
+	postType = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Post",
+		Interfaces: []*graphql.Interface{postNode},
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"title": &graphql.Field{Type: graphql.String},
+		},
+	})
+	posts, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "PostsQuery",
+			Fields: graphql.Fields{
+				"feed": &graphql.Field{
+					Type: graphql.NewList(postNode),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{&mergeTestPost{ID: "p1", Title: "Hello"}}, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "PostsMutation",
+			Fields: graphql.Fields{
+				"createPost": &graphql.Field{
+					Type: postType,
+					Args: graphql.FieldConfigArgument{
+						"title": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &mergeTestPost{ID: "p2", Title: p.Args["title"].(string)}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := graphql.MergeSchemas([]graphql.Schema{users, posts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := merged.QueryType().Name(); name != "Query" {
+		t.Fatalf("Expected the merged query type to be named Query, got %s", name)
+	}
+	if merged.Type("PostsQuery") != nil {
+		t.Fatal("Expected the PostsQuery type to be merged into Query")
+	}
+	if possible := merged.PossibleTypes(merged.Type("Node").(*graphql.Interface)); len(possible) != 2 {
+		t.Fatalf("Expected Node to have 2 possible types, got %v", possible)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: merged,
+		RequestString: `{
+			user { id name }
+			feed { id ... on Post { title } }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"id": "u1", "name": "Ann"},
+			"feed": []interface{}{
+				map[string]interface{}{"id": "p1", "title": "Hello"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        merged,
+		RequestString: `mutation { createPost(title: "New") { id title } }`,
+	})
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"createPost": map[string]interface{}{"id": "p2", "title": "New"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The merged schemas are unchanged.
+	if _, ok := users.QueryType().Fields()["feed"]; ok {
+		t.Fatal("Expected the users schema to not have a feed field")
+	}
+	if users.Type("Post") != nil {
+		t.Fatal("Expected the users schema to not have the Post type")
+	}
+}
+
+func TestMergeSchemas_RejectsConflictingTypes(t *testing.T) {
+	tests := []struct {
+		sdls []string
+		err  string
+	}{
+		{
+			sdls: []string{
+				`type Query { user: User } type User { id: ID! }`,
+				`type Query { me: User } type User { id: String }`,
+			},
+			err: `Field "User.id" has conflicting definitions "ID!" and "String" in the merged schemas.`,
+		},
+		{
+			sdls: []string{
+				`type Query { users(first: Int): [String] }`,
+				`type Query { users(last: Int): [String] }`,
+			},
+			err: `Field "Query.users" has conflicting definitions "(first: Int): [String]" and "(last: Int): [String]" in the merged schemas.`,
+		},
+		{
+			sdls: []string{
+				`type Query { status: Status } enum Status { ACTIVE }`,
+				`type Query { other: Status } type Status { active: Boolean }`,
+			},
+			err: `Cannot merge enum "Status" with type "Status".`,
+		},
+		{
+			sdls: []string{
+				`type Query { at: Time } scalar Time @specifiedBy(url: "https://example.com/rfc3339")`,
+				`type Query { since: Time } scalar Time`,
+			},
+			err: `Scalar "Time" has conflicting specification URLs "https://example.com/rfc3339" and "" in the merged schemas.`,
+		},
+		{
+			sdls: []string{
+				`type Query { user: String } directive @auth(role: String) on FIELD_DEFINITION`,
+				`type Query { me: String } directive @auth on FIELD_DEFINITION | OBJECT`,
+			},
+			err: `Directive "@auth" has conflicting definitions "(role: String) on FIELD_DEFINITION" and "on FIELD_DEFINITION | OBJECT" in the merged schemas.`,
+		},
+	}
+	for _, test := range tests {
+		var schemas []graphql.Schema
+		for _, sdl := range test.sdls {
+			schema, err := graphql.BuildSchema(sdl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schemas = append(schemas, schema)
+		}
+		_, err := graphql.MergeSchemas(schemas)
+		if err == nil {
+			t.Fatalf("Expected error %q merging %v", test.err, test.sdls)
+		}
+		if err.Error() != test.err {
+			t.Fatalf("Expected error %q, got %q", test.err, err.Error())
+		}
+	}
+}
+
+func TestMergeSchemas_RejectsConflictingEnumValues(t *testing.T) {
+	newSchema := func(active interface{}) graphql.Schema {
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"status": &graphql.Field{
+						Type: graphql.NewEnum(graphql.EnumConfig{
+							Name: "Status",
+							Values: graphql.EnumValueConfigMap{
+								"ACTIVE": &graphql.EnumValueConfig{Value: active},
+							},
+						}),
+					},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+
+	if _, err := graphql.MergeSchemas([]graphql.Schema{newSchema(1), newSchema(1)}); err != nil {
+		t.Fatalf("Unexpected error merging enums with the same values: %v", err)
+	}
+	_, err := graphql.MergeSchemas([]graphql.Schema{newSchema(1), newSchema(2)})
+	if expected := `Enum value "Status.ACTIVE" has conflicting values 1 and 2 in the merged schemas.`; err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
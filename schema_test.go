@@ -0,0 +1,189 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestSchema_ImplementingTypes(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  node: Node
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+}
+
+type Group implements Node {
+  id: ID!
+}
+
+type Tag {
+  id: ID!
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, obj := range schema.ImplementingTypes("Node") {
+		names = append(names, obj.Name())
+	}
+	expected := []string{"Group", "User"}
+	if !reflect.DeepEqual(expected, names) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, names))
+	}
+
+	if impls := schema.ImplementingTypes("User"); impls != nil {
+		t.Fatalf("Expected no implementations of an object type, got %v", impls)
+	}
+	if impls := schema.ImplementingTypes("Unknown"); impls != nil {
+		t.Fatalf("Expected no implementations of an unknown type, got %v", impls)
+	}
+}
+
+func TestSchema_RejectsIncompleteVisibleSchemas(t *testing.T) {
+	secretType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Secret",
+		Fields: graphql.Fields{
+			"code": &graphql.Field{Type: graphql.String},
+		},
+	})
+	secretFilter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "SecretFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"code": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	nodeType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{nodeType},
+		Fields: graphql.Fields{
+			"id":     &graphql.Field{Type: graphql.ID},
+			"secret": &graphql.Field{Type: secretType},
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: secretFilter},
+				},
+			},
+		},
+	})
+	newSchema := func(hidden ...string) error {
+		_, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"user": &graphql.Field{Type: userType},
+				},
+			}),
+			IsVisible: func(kind, name string) bool {
+				for _, h := range hidden {
+					if h == kind+" "+name {
+						return false
+					}
+				}
+				return true
+			},
+		})
+		return err
+	}
+
+	if err := newSchema(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := newSchema("TYPE Secret", "FIELD User.secret", "TYPE SecretFilter", "FIELD User.name"); err != nil {
+		t.Fatalf("Unexpected error hiding types along with their fields: %v", err)
+	}
+
+	for _, tc := range []struct {
+		hidden   []string
+		expected string
+	}{
+		{
+			hidden:   []string{"TYPE Secret"},
+			expected: `Field "User.secret" is visible but its type "Secret" is hidden.`,
+		},
+		{
+			hidden:   []string{"TYPE SecretFilter"},
+			expected: `Argument "User.name(filter:)" is visible but its type "SecretFilter" is hidden.`,
+		},
+		{
+			hidden:   []string{"FIELD User.id"},
+			expected: `Field "User.id" is hidden but implements the visible field "Node.id".`,
+		},
+		{
+			hidden:   []string{"TYPE Query"},
+			expected: `Root type "Query" cannot be hidden.`,
+		},
+	} {
+		err := newSchema(tc.hidden...)
+		if err == nil || err.Error() != tc.expected {
+			t.Errorf("Hiding %v: expected error %q, got %v", tc.hidden, tc.expected, err)
+		}
+	}
+}
+
+func TestSchema_FieldVisibilityIsWorkedOutOncePerSchema(t *testing.T) {
+	var fieldChecks int
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"name": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "Jane", nil
+					},
+				},
+				"secret": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		IsVisible: func(kind, name string) bool {
+			if kind == graphql.VisibilityField {
+				fieldChecks++
+			}
+			return name != "Query.secret"
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	checksAfterNewSchema := fieldChecks
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ name }`,
+		})
+		if len(result.Errors) != 0 {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+	}
+	if fieldChecks != checksAfterNewSchema {
+		t.Fatalf("Expected IsVisible not to be called for fields after NewSchema, got %d more calls", fieldChecks-checksAfterNewSchema)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ secret }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "secret" on type "Query".` {
+		t.Fatalf("Expected the hidden field to be rejected, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,114 @@
+package graphql
+
+import "sort"
+
+// SchemaVisitor holds the functions Schema.Walk calls for each element of the
+// schema. Any of them may be nil.
+type SchemaVisitor struct {
+	// Type is called for each named type.
+	Type func(ttype Type)
+	// Field is called for each field of an object or interface.
+	Field func(parent Type, field *FieldDefinition)
+	// FieldArgument is called for each argument of a field.
+	FieldArgument func(parent Type, field *FieldDefinition, arg *Argument)
+	// InputField is called for each field of an input object.
+	InputField func(parent *InputObject, field *InputObjectField)
+	// EnumValue is called for each value of an enum.
+	EnumValue func(parent *Enum, value *EnumValueDefinition)
+	// Directive is called for each directive.
+	Directive func(directive *Directive)
+	// DirectiveArgument is called for each argument of a directive.
+	DirectiveArgument func(directive *Directive, arg *Argument)
+}
+
+// Walk calls the visitor's functions for every type, field, argument, input
+// field, enum value, and directive of the schema. Types are visited in order of
+// their name, each followed by its members also in order of their name, and
+// then directives are visited in the order they were defined.
+func (gq *Schema) Walk(v SchemaVisitor) {
+	typeMap := gq.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ttype := typeMap[name]
+		if v.Type != nil {
+			v.Type(ttype)
+		}
+		switch ttype := ttype.(type) {
+		case *Object:
+			walkFields(v, ttype, ttype.Fields())
+		case *Interface:
+			walkFields(v, ttype, ttype.Fields())
+		case *InputObject:
+			if v.InputField == nil {
+				continue
+			}
+			fields := ttype.Fields()
+			fieldNames := make([]string, 0, len(fields))
+			for name := range fields {
+				fieldNames = append(fieldNames, name)
+			}
+			sort.Strings(fieldNames)
+			for _, name := range fieldNames {
+				v.InputField(ttype, fields[name])
+			}
+		case *Enum:
+			if v.EnumValue == nil {
+				continue
+			}
+			values := append([]*EnumValueDefinition(nil), ttype.Values()...)
+			sort.Slice(values, func(i, j int) bool {
+				return values[i].Name < values[j].Name
+			})
+			for _, value := range values {
+				v.EnumValue(ttype, value)
+			}
+		}
+	}
+
+	for _, directive := range gq.Directives() {
+		if v.Directive != nil {
+			v.Directive(directive)
+		}
+		if v.DirectiveArgument != nil {
+			for _, arg := range sortedArguments(directive.Args) {
+				v.DirectiveArgument(directive, arg)
+			}
+		}
+	}
+}
+
+func walkFields(v SchemaVisitor, parent Type, fields FieldDefinitionMap) {
+	if v.Field == nil && v.FieldArgument == nil {
+		return
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := fields[name]
+		if v.Field != nil {
+			v.Field(parent, field)
+		}
+		if v.FieldArgument != nil {
+			for _, arg := range sortedArguments(field.Args) {
+				v.FieldArgument(parent, field, arg)
+			}
+		}
+	}
+}
+
+// sortedArguments returns a copy of args sorted by name as they're collected
+// from a map.
+func sortedArguments(args []*Argument) []*Argument {
+	sorted := append([]*Argument(nil), args...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].PrivateName < sorted[j].PrivateName
+	})
+	return sorted
+}
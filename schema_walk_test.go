@@ -0,0 +1,105 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sprucehealth/graphql"
+	"github.com/sprucehealth/graphql/testutil"
+)
+
+func TestSchemaWalk_VisitsAllCoordinatesInOrder(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  user(id: ID!, role: Role = MEMBER): User
+  search(filter: Filter): [Node]
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String
+  role: Role
+}
+
+enum Role { MEMBER ADMIN }
+
+input Filter {
+  name: String
+  roles: [Role!]
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var coordinates []string
+	user := func(name string) bool { return !strings.HasPrefix(name, "__") }
+	schema.Walk(graphql.SchemaVisitor{
+		Type: func(ttype graphql.Type) {
+			if _, ok := ttype.(*graphql.Scalar); !ok && user(ttype.Name()) {
+				coordinates = append(coordinates, ttype.Name())
+			}
+		},
+		Field: func(parent graphql.Type, field *graphql.FieldDefinition) {
+			if user(parent.Name()) {
+				coordinates = append(coordinates, parent.Name()+"."+field.Name)
+			}
+		},
+		FieldArgument: func(parent graphql.Type, field *graphql.FieldDefinition, arg *graphql.Argument) {
+			if user(parent.Name()) {
+				coordinates = append(coordinates, parent.Name()+"."+field.Name+"("+arg.Name()+":)")
+			}
+		},
+		InputField: func(parent *graphql.InputObject, field *graphql.InputObjectField) {
+			coordinates = append(coordinates, parent.Name()+"."+field.Name())
+		},
+		EnumValue: func(parent *graphql.Enum, value *graphql.EnumValueDefinition) {
+			if user(parent.Name()) {
+				coordinates = append(coordinates, parent.Name()+"."+value.Name)
+			}
+		},
+		Directive: func(directive *graphql.Directive) {
+			coordinates = append(coordinates, "@"+directive.Name)
+		},
+		DirectiveArgument: func(directive *graphql.Directive, arg *graphql.Argument) {
+			coordinates = append(coordinates, "@"+directive.Name+"("+arg.Name()+":)")
+		},
+	})
+
+	expected := []string{
+		"Filter",
+		"Filter.name",
+		"Filter.roles",
+		"Node",
+		"Node.id",
+		"Query",
+		"Query.search",
+		"Query.search(filter:)",
+		"Query.user",
+		"Query.user(id:)",
+		"Query.user(role:)",
+		"Role",
+		"Role.ADMIN",
+		"Role.MEMBER",
+		"User",
+		"User.id",
+		"User.name",
+		"User.role",
+		"@include",
+		"@include(if:)",
+		"@skip",
+		"@skip(if:)",
+		"@deprecated",
+		"@deprecated(reason:)",
+		"@specifiedBy",
+		"@specifiedBy(url:)",
+	}
+	if !reflect.DeepEqual(expected, coordinates) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, coordinates))
+	}
+}
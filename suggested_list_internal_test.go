@@ -20,9 +20,35 @@ func TestSuggestionList_ReturnsEmptyArrayWhenThereAreNoOptions(t *testing.T) {
 	}
 }
 func TestSuggestionList_ReturnsOptionsSortedBasedOnSimilarity(t *testing.T) {
-	expected := []string{"abc", "ab"}
+	expected := []string{"abc", "ab", "a"}
 	result := suggestionList("abc", []string{"a", "ab", "abc"})
 	if !reflect.DeepEqual(expected, result) {
 		t.Fatalf("Expected %v, got: %v", expected, result)
 	}
 }
+func TestSuggestionList_MatchesGraphQLJS(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  []string
+		expected []string
+	}{
+		{"greenish", []string{"green"}, []string{"green"}},
+		{"green", []string{"greenish"}, []string{"greenish"}},
+		{"aaaa", []string{"aaab"}, []string{"aaab"}},
+		{"aaaa", []string{"aabb"}, []string{"aabb"}},
+		{"aaaa", []string{"abbb"}, []string{}},
+		{"ab", []string{"ca"}, []string{}},
+		{"verylongstring", []string{"VERYLONGSTRING"}, []string{"VERYLONGSTRING"}},
+		{"VERYLONGSTRING", []string{"VeryLongString"}, []string{"VeryLongString"}},
+		{"agr", []string{"arg"}, []string{"arg"}},
+		{"214365879", []string{"123456789"}, []string{"123456789"}},
+		{"a", []string{"az", "ax", "ay"}, []string{"ax", "ay", "az"}},
+		{"boo", []string{"moo", "foo", "zoo"}, []string{"foo", "moo", "zoo"}},
+		{"abc", []string{"abc10", "abc2", "abc1"}, []string{"abc1", "abc2", "abc10"}},
+	}
+	for _, test := range tests {
+		if result := suggestionList(test.input, test.options); !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("suggestionList(%q, %q): expected %q, got: %q", test.input, test.options, test.expected, result)
+		}
+	}
+}
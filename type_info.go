@@ -244,9 +244,15 @@ func DefaultTypeInfoFieldDef(schema *Schema, parentType Type, fieldAST *ast.Fiel
 	}
 
 	if parentType, ok := parentType.(*Object); ok && parentType != nil {
+		if !schema.fieldVisible(parentType.Name(), name) {
+			return nil
+		}
 		return parentType.Fields()[name]
 	}
 	if parentType, ok := parentType.(*Interface); ok && parentType != nil {
+		if !schema.fieldVisible(parentType.Name(), name) {
+			return nil
+		}
 		return parentType.Fields()[name]
 	}
 	return nil
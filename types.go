@@ -9,6 +9,12 @@ import (
 type Result struct {
 	Data   interface{}                `json:"data"`
 	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+	// Extensions are the values contributed by resolvers through
+	// ExtensionsFromContext.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+	// RawErrors are the original errors behind Errors, in the same order,
+	// when requested with Params.RawErrors. They're never encoded.
+	RawErrors []error `json:"-"`
 }
 
 func (r *Result) HasErrors() bool {
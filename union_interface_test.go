@@ -163,7 +163,7 @@ func TestUnionIntersectionTypes_CanIntrospectOnUnionAndIntersectionTypes(t *test
 						"name": "name",
 					},
 				},
-				"interfaces": nil,
+				"interfaces": []interface{}{},
 				"possibleTypes": []interface{}{
 					map[string]interface{}{
 						"name": "Dog",
@@ -585,3 +585,99 @@ func TestUnionIntersectionTypes_GetsExecutionInfoInResolver(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(schema2, encounteredSchema))
 	}
 }
+
+func TestUnionIntersectionTypes_ResolvesFieldsThroughInterfaceHierarchy(t *testing.T) {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	entityInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Entity",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{entityInterface, nodeInterface},
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String},
+		},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+			return true
+		},
+	})
+	user := map[string]interface{}{"id": "1", "name": "Ann", "email": "ann@example.com"}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"entity": &graphql.Field{
+					Type: entityInterface,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return user, nil
+					},
+				},
+				"node": &graphql.Field{
+					Type: nodeInterface,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return user, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{userType},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			entity {
+				... on Node { id }
+				name
+			}
+			node {
+				id
+				... on Entity { name }
+				... on User { email }
+			}
+			__type(name: "Entity") {
+				interfaces { name }
+				possibleTypes { name }
+			}
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"entity": map[string]interface{}{
+				"id":   "1",
+				"name": "Ann",
+			},
+			"node": map[string]interface{}{
+				"id":    "1",
+				"name":  "Ann",
+				"email": "ann@example.com",
+			},
+			"__type": map[string]interface{}{
+				"interfaces": []interface{}{
+					map[string]interface{}{"name": "Node"},
+				},
+				"possibleTypes": []interface{}{
+					map[string]interface{}{"name": "User"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -1,10 +1,13 @@
 package graphql_test
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
 	"github.com/sprucehealth/graphql/language/ast"
+	"github.com/sprucehealth/graphql/testutil"
 )
 
 var someScalarType = graphql.NewScalar(graphql.ScalarConfig{
@@ -314,11 +317,41 @@ func TestTypeSystem_SchemaMustHaveObjectRootTypes_AcceptsASchemaWhoseQueryAndSub
 }
 func TestTypeSystem_SchemaMustHaveObjectRootTypes_RejectsASchemaWithoutAQueryType(t *testing.T) {
 	_, err := graphql.NewSchema(graphql.SchemaConfig{})
-	expectedError := "Schema query must be Object Type but got: nil."
+	expectedError := "Schema must contain a Query root type."
 	if err == nil || err.Error() != expectedError {
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
 }
+func TestTypeSystem_SchemaMustHaveObjectRootTypes_RejectsASchemaWithOnlyAMutationType(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"edit": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	expectedError := "Schema must contain a Query root type."
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+
+	// Using the schema anyway reports the same error rather than panicking.
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { edit }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, result.Errors)
+	}
+	result = graphql.Execute(graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `mutation { edit }`),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, result.Errors)
+	}
+}
 
 func TestTypeSystem_SchemaMustContainUniquelyNamedTypes_RejectsASchemaWhichRedefinesABuiltInType(t *testing.T) {
 	fakeString := graphql.NewScalar(graphql.ScalarConfig{
@@ -1306,6 +1339,107 @@ func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_RejectsAnObjectMis
 	}
 }
 
+func TestTypeSystem_NewSchema_ReportsEveryProblem(t *testing.T) {
+	anotherInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+		Fields: graphql.Fields{
+			"field":      &graphql.Field{Type: graphql.String},
+			"otherField": &graphql.Field{Type: graphql.String},
+		},
+	})
+	anotherObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*graphql.Interface{anotherInterface},
+		Fields: graphql.Fields{
+			"anotherfield": &graphql.Field{Type: graphql.String},
+		},
+	})
+	badInputObject := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "BadInputObject",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"object": &graphql.InputObjectFieldConfig{Type: anotherObject},
+		},
+	})
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"f": &graphql.Field{Type: anotherObject},
+			},
+		}),
+		Types: []graphql.Type{badInputObject},
+	})
+	errs, ok := err.(graphql.SchemaErrors)
+	if !ok {
+		t.Fatalf("Expected graphql.SchemaErrors, got %T: %v", err, err)
+	}
+	expected := []string{
+		`"AnotherInterface" expects field "field" but "AnotherObject" does not provide it.`,
+		`"AnotherInterface" expects field "otherField" but "AnotherObject" does not provide it.`,
+		`BadInputObject.object field type must be Input Type but got: AnotherObject.`,
+	}
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	if !reflect.DeepEqual(expected, messages) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, messages))
+	}
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected error message: %s", err.Error())
+	}
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement(t *testing.T) {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	entityInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Entity",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.String},
+		},
+	})
+	namedEntityInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "NamedEntity",
+		Interfaces: []*graphql.Interface{entityInterface},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"entity": &graphql.Field{Type: namedEntityInterface},
+			},
+		}),
+	})
+	errs, ok := err.(graphql.SchemaErrors)
+	if !ok {
+		t.Fatalf("Expected graphql.SchemaErrors, got %T: %v", err, err)
+	}
+	expected := []string{
+		`Node.id expects type "ID!" but Entity.id provides type "String".`,
+		`Type NamedEntity must implement Node because it is implemented by Entity.`,
+		`"Entity" expects field "id" but "NamedEntity" does not provide it.`,
+	}
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	if !reflect.DeepEqual(expected, messages) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, messages))
+	}
+}
+
 func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_RejectsAnObjectWithAnIncorrectlyTypedInterfaceField(t *testing.T) {
 	anotherInterface := graphql.NewInterface(graphql.InterfaceConfig{
 		Name: "AnotherInterface",
@@ -1,6 +1,9 @@
 package graphql
 
 import (
+	"crypto/sha256"
+	"fmt"
+
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
 	"github.com/sprucehealth/graphql/language/visitor"
@@ -9,6 +12,35 @@ import (
 type ValidationResult struct {
 	IsValid bool
 	Errors  []gqlerrors.FormattedError
+	// Details describes the error at the same index in Errors for tools such
+	// as editor integrations.
+	Details []ValidationErrorDetail
+}
+
+// ValidationErrorDetail is machine-readable information about a validation error.
+type ValidationErrorDetail struct {
+	// Rule is the name of the rule that reported the error without its "Rule"
+	// suffix (e.g. "FieldsOnCorrectType").
+	Rule string
+	// NodeKinds are the kinds of the AST nodes the error was reported on (e.g. "Field").
+	NodeKinds []string
+}
+
+// ValidationCache stores validation results so that a request validated against
+// the same schema isn't validated again. Keys identify both the document and the
+// schema. Implementations must be safe for concurrent use.
+type ValidationCache interface {
+	Get(key string) (ValidationResult, bool)
+	Set(key string, result ValidationResult)
+}
+
+// validationCacheKey returns the cache key for validating the request string
+// against the schema, which is also used for the DocumentCache. Schemas are
+// identified by the id given to them by NewSchema, which unlike their address
+// is never reused.
+func validationCacheKey(schema *Schema, requestString string) string {
+	h := sha256.Sum256([]byte(requestString))
+	return fmt.Sprintf("%d:%x", schema.id, h)
 }
 
 // ValidateDocument implements the "Validation" section of the spec.
@@ -32,6 +64,10 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError("Must provide schema"))
 		return vr
 	}
+	if schema.QueryType() == nil {
+		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError(errMissingQueryType))
+		return vr
+	}
 	if astDoc == nil {
 		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError("Must provide document"))
 		return vr
@@ -39,7 +75,9 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 	typeInfo := NewTypeInfo(&TypeInfoConfig{
 		Schema: schema,
 	})
-	vr.Errors = VisitUsingRules(schema, typeInfo, astDoc, rules)
+	context := visitUsingRules(schema, typeInfo, astDoc, rules)
+	vr.Errors = context.Errors()
+	vr.Details = context.details
 	vr.IsValid = len(vr.Errors) == 0
 	return vr
 }
@@ -51,6 +89,10 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 // Had to expose it to unit test experimental customizable validation feature,
 // but not meant for public consumption
 func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
+	return visitUsingRules(schema, typeInfo, astDoc, rules).Errors()
+}
+
+func visitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) *ValidationContext {
 	context := NewValidationContext(schema, astDoc, typeInfo)
 
 	var visitInstance func(astNode ast.Node, instance *ValidationRuleInstance)
@@ -100,9 +142,11 @@ func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, r
 	}
 
 	for _, rule := range rules {
-		visitInstance(astDoc, rule(context))
+		instance := rule(context)
+		context.rule = instance.Name
+		visitInstance(astDoc, instance)
 	}
-	return context.Errors()
+	return context
 }
 
 type HasSelectionSet interface {
@@ -128,6 +172,9 @@ type ValidationContext struct {
 	recursivelyReferencedFragments map[*ast.OperationDefinition][]*ast.FragmentDefinition
 	fragmentSpreads                map[HasSelectionSet][]*ast.FragmentSpread
 	errors                         []gqlerrors.FormattedError
+	details                        []ValidationErrorDetail
+	// rule is the name of the rule being run
+	rule string
 }
 
 func NewValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeInfo) *ValidationContext {
@@ -145,6 +192,13 @@ func NewValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeIn
 func (ctx *ValidationContext) ReportError(err error) {
 	formattedErr := gqlerrors.FormatError(err)
 	ctx.errors = append(ctx.errors, formattedErr)
+	detail := ValidationErrorDetail{Rule: ctx.rule}
+	if err, ok := err.(*gqlerrors.Error); ok {
+		for _, node := range err.Nodes {
+			detail.NodeKinds = append(detail.NodeKinds, safeNodeType(node))
+		}
+	}
+	ctx.details = append(ctx.details, detail)
 }
 
 func (ctx *ValidationContext) Errors() []gqlerrors.FormattedError {
@@ -0,0 +1,31 @@
+package graphql
+
+import "testing"
+
+func TestValidationCacheKey_IdentifiesSchemas(t *testing.T) {
+	newSchema := func() Schema {
+		schema, err := NewSchema(SchemaConfig{
+			Query: NewObject(ObjectConfig{
+				Name: "Query",
+				Fields: Fields{
+					"a": &Field{Type: String},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	a, b := newSchema(), newSchema()
+	copied := a
+	if validationCacheKey(&a, "{ a }") != validationCacheKey(&copied, "{ a }") {
+		t.Error("Expected copies of a schema to have the same key")
+	}
+	if validationCacheKey(&a, "{ a }") == validationCacheKey(&b, "{ a }") {
+		t.Error("Expected different schemas to have different keys")
+	}
+	if validationCacheKey(&a, "{ a }") == validationCacheKey(&a, "{ b }") {
+		t.Error("Expected different requests to have different keys")
+	}
+}
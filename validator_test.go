@@ -171,3 +171,160 @@ func TestValidator_SupportsFullValidation_ValidatesUsingACustomTypeInfo(t *testi
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, errors))
 	}
 }
+
+func TestValidator_ReportsRuleNamesAndNodeKinds(t *testing.T) {
+	ast := testutil.TestParse(t, `
+      query Q($unused: Int) {
+        dog {
+          meowVolume
+        }
+      }
+    `)
+	result := graphql.ValidateDocument(testutil.TestSchema, ast, nil)
+	expected := []graphql.ValidationErrorDetail{
+		{Rule: "FieldsOnCorrectType", NodeKinds: []string{"Field"}},
+		{Rule: "NoUnusedVariables", NodeKinds: []string{"VariableDefinition"}},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result.Details) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Details))
+	}
+}
+
+func TestValidator_ReportsNamesOfRulesBuiltByConstructors(t *testing.T) {
+	ast := testutil.TestParse(t, `
+      {
+        dog {
+          name
+          nickname
+        }
+      }
+    `)
+	result := graphql.ValidateDocument(testutil.TestSchema, ast, []graphql.ValidationRuleFn{
+		graphql.MaxComplexityRule(1),
+		graphql.NewMaxFieldCountRule(1),
+	})
+	expected := []graphql.ValidationErrorDetail{
+		{Rule: "MaxComplexity", NodeKinds: []string{"OperationDefinition"}},
+		{Rule: "MaxFieldCount", NodeKinds: []string{"OperationDefinition"}},
+	}
+	if !reflect.DeepEqual(expected, result.Details) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Details))
+	}
+}
+
+func TestValidator_RejectsInvalidFragmentTypeConditions(t *testing.T) {
+	ast := testutil.TestParse(t, `
+      {
+        dog {
+          ...unknownFragment
+          ... on Boolean {
+            name
+          }
+        }
+      }
+      fragment unknownFragment on NotAType {
+        name
+      }
+    `)
+	result := graphql.ValidateDocument(testutil.TestSchema, ast, nil)
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment cannot condition on non composite type "Boolean".`, 5, 18),
+		testutil.RuleError(`Unknown type "NotAType". Did you mean "__Type"?`, 10, 35),
+	}
+	expectedDetails := []graphql.ValidationErrorDetail{
+		{Rule: "FragmentsOnCompositeTypes", NodeKinds: []string{"Named"}},
+		{Rule: "KnownTypeNames", NodeKinds: []string{"Named"}},
+	}
+	for i := range expected {
+		expected[i].Type = gqlerrors.ErrorTypeBadQuery
+	}
+	if !reflect.DeepEqual(expected, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+	if !reflect.DeepEqual(expectedDetails, result.Details) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedDetails, result.Details))
+	}
+}
+
+func TestValidator_RejectsFragmentCyclesAndUnusedOrUnknownFragments(t *testing.T) {
+	ast := testutil.TestParse(t, `
+      {
+        dog {
+          ...fragA
+          ...undefinedFragment
+        }
+      }
+      fragment fragA on Dog { ...fragB }
+      fragment fragB on Dog { ...fragA }
+      fragment unusedFragment on Dog { name }
+    `)
+	result := graphql.ValidateDocument(testutil.TestSchema, ast, nil)
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown fragment "undefinedFragment".`, 5, 14),
+		testutil.RuleError(`Cannot spread fragment "fragA" within itself via fragB.`, 8, 31, 9, 31),
+		testutil.RuleError(`Fragment "unusedFragment" is never used.`, 10, 7),
+	}
+	for i := range expected {
+		expected[i].Type = gqlerrors.ErrorTypeBadQuery
+	}
+	if !reflect.DeepEqual(expected, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
+
+func TestValidator_RejectsUnusedUndefinedDuplicateAndNonInputVariables(t *testing.T) {
+	ast := testutil.TestParse(t, `
+      query Foo($unused: String, $atOtherHomes: Boolean, $atOtherHomes: Boolean, $dog: Dog) {
+        dog {
+          isHousetrained(atOtherHomes: $atOtherHomes)
+          doesKnowCommand(dogCommand: $command)
+          name @include(if: $dog)
+        }
+      }
+    `)
+	result := graphql.ValidateDocument(testutil.TestSchema, ast, nil)
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$command" is not defined by operation "Foo".`, 5, 39, 2, 7),
+		testutil.RuleError(`Variable "$unused" is never used in operation "Foo".`, 2, 17),
+		testutil.RuleError(`There can only be one variable named "atOtherHomes".`, 2, 35, 2, 59),
+		testutil.RuleError(`Variable "$dog" cannot be non-input type "Dog".`, 2, 88),
+		testutil.RuleError(`Variable "$dog" of type "Dog" used in position expecting type "Boolean!".`, 2, 82, 6, 29),
+	}
+	for i := range expected {
+		expected[i].Type = gqlerrors.ErrorTypeBadQuery
+	}
+	if !reflect.DeepEqual(expected, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
+
+func TestValidator_RejectsUnknownFieldsAndArgumentsWithSuggestions(t *testing.T) {
+	ast := testutil.TestParse(t, `
+      {
+        dog {
+          nam
+          doesKnowCommand(dogComand: SIT)
+          isHousetrained(atOtherHome: true)
+        }
+        complicatedArgs {
+          multipleReqs(req1: 1)
+        }
+      }
+    `)
+	result := graphql.ValidateDocument(testutil.TestSchema, ast, nil)
+	expected := []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "nam" on type "Dog". Did you mean "name"?`, 4, 11),
+		testutil.RuleError(`Unknown argument "dogComand" on field "doesKnowCommand" of type "Dog". Did you mean "dogCommand"?`, 5, 27),
+		testutil.RuleError(`Unknown argument "atOtherHome" on field "isHousetrained" of type "Dog". Did you mean "atOtherHomes"?`, 6, 26),
+		testutil.RuleError(`Field "multipleReqs" argument "req2" of type "Int!" is required but not provided.`, 9, 11),
+	}
+	for i := range expected {
+		expected[i].Type = gqlerrors.ErrorTypeBadQuery
+	}
+	if !reflect.DeepEqual(expected, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected, result.Errors))
+	}
+}
@@ -1,12 +1,17 @@
 package graphql
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/sprucehealth/graphql/gqlerrors"
 	"github.com/sprucehealth/graphql/language/ast"
@@ -16,25 +21,58 @@ import (
 // Prepares an object map of variableValues of the correct type based on the
 // provided variable definitions and arbitrary input. If the input cannot be
 // parsed to match the variable definitions, a GraphQLError will be returned.
-func getVariableValues(schema Schema, definitionASTs []*ast.VariableDefinition, inputs map[string]interface{}) (map[string]interface{}, error) {
+func getVariableValues(ctx context.Context, schema Schema, definitionASTs []*ast.VariableDefinition, inputs map[string]interface{}, lenientBools bool) (map[string]interface{}, error) {
 	values := make(map[string]interface{}, len(definitionASTs))
 	for _, defAST := range definitionASTs {
 		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		varValue, err := getVariableValue(schema, defAST, inputs[varName])
+		input, provided := inputs[varName]
+		varValue, err := getVariableValue(ctx, schema, defAST, input, provided, lenientBools)
 		if err != nil {
 			return values, err
 		}
+		// Omitted variables without a default are left out so they can be
+		// told apart from variables that were explicitly set to null.
+		if !provided && varValue == nil {
+			continue
+		}
 		values[varName] = varValue
 	}
 	return values, nil
 }
 
+// coercedVariableValues returns the already coerced inputs for the given variable
+// definitions, using the definition's default value for any missing variable.
+// Variables that are explicitly null are kept as null.
+func coercedVariableValues(schema Schema, definitionASTs []*ast.VariableDefinition, inputs map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(definitionASTs))
+	for _, defAST := range definitionASTs {
+		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
+			continue
+		}
+		varName := defAST.Variable.Name.Value
+		if value, ok := inputs[varName]; ok {
+			if isNullish(value) {
+				value = nil
+			}
+			values[varName] = value
+			continue
+		}
+		if defAST.DefaultValue == nil {
+			continue
+		}
+		if ttype, err := typeFromAST(schema, defAST.Type); err == nil && ttype != nil {
+			values[varName] = valueFromAST(defAST.DefaultValue, ttype, nil)
+		}
+	}
+	return values
+}
+
 // Prepares an object map of argument values given a list of argument
 // definitions and list of argument AST nodes.
-func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVariables map[string]interface{}) (map[string]interface{}, error) {
+func getArgumentValues(ctx context.Context, argDefs []*Argument, argASTs []*ast.Argument, variableVariables map[string]interface{}, literals *literalCache) (map[string]interface{}, error) {
 	argASTMap := make(map[string]*ast.Argument, len(argASTs))
 	for _, argAST := range argASTs {
 		if argAST.Name != nil {
@@ -48,8 +86,26 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 		if argAST, ok := argASTMap[name]; ok {
 			valueAST = argAST.Value
 		}
-		value := valueFromAST(valueAST, argDef.Type, variableVariables)
+		var value interface{}
+		switch valueAST.(type) {
+		case *ast.ObjectValue, *ast.ListValue:
+			var err error
+			value, err = literals.coerce(name, argDef.Type, valueAST, variableVariables)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			value = valueFromAST(valueAST, argDef.Type, variableVariables)
+		}
+		if err := reportDeprecatedInputs(ctx, deprecatedLiteralInputs(argDef.Type, valueAST)); err != nil {
+			return nil, err
+		}
 		if isNullish(value) {
+			// An explicit null overrides the default.
+			if argDef.DefaultValue != nil && isExplicitNull(valueAST, variableVariables) {
+				results[name] = nil
+				continue
+			}
 			value = argDef.DefaultValue
 		}
 		if !isNullish(value) {
@@ -59,15 +115,254 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 	return results, nil
 }
 
+// literalCache holds the coerced values of the list and input object literals
+// of arguments for the duration of a request, so that a field resolved many
+// times (e.g. for each item of a list) coerces its literals only once. Values
+// are keyed by the literal's AST node and the type it's coerced to. Each
+// caller gets its own copy of the cached objects and lists, so a resolver
+// modifying its arguments doesn't affect others. A nil *literalCache doesn't
+// cache. It's safe for concurrent use.
+type literalCache struct {
+	mu     sync.Mutex
+	values map[literalCacheKey]literalCacheEntry
+}
+
+type literalCacheKey struct {
+	valueAST ast.Value
+	ttype    Input
+}
+
+type literalCacheEntry struct {
+	value interface{}
+	err   error
+}
+
+// coerce returns the value of the argument's list or input object literal.
+// Literal input objects aren't otherwise checked when the document wasn't
+// validated, so unknown and missing required fields are rejected here.
+func (c *literalCache) coerce(name string, ttype Input, valueAST ast.Value, variables map[string]interface{}) (interface{}, error) {
+	key := literalCacheKey{valueAST: valueAST, ttype: ttype}
+	if c != nil {
+		c.mu.Lock()
+		entry, ok := c.values[key]
+		c.mu.Unlock()
+		if ok {
+			return copyValue(entry.value), entry.err
+		}
+	}
+	var entry literalCacheEntry
+	if entry.err = invalidArgumentError(name, ttype, valueAST); entry.err == nil {
+		entry.value = valueFromAST(valueAST, ttype, variables)
+	}
+	if c != nil {
+		c.mu.Lock()
+		if c.values == nil {
+			c.values = make(map[literalCacheKey]literalCacheEntry)
+		}
+		c.values[key] = entry
+		c.mu.Unlock()
+		return copyValue(entry.value), entry.err
+	}
+	return entry.value, entry.err
+}
+
+// invalidArgumentError returns the error for the argument's literal value if
+// it isn't valid for the type, located at the literals that were rejected.
+func invalidArgumentError(name string, ttype Input, valueAST ast.Value) error {
+	isValid, messages := isValidLiteralValue(ttype, valueAST)
+	if isValid {
+		return nil
+	}
+	return gqlerrors.NewError(
+		gqlerrors.ErrorTypeInvalidInput,
+		fmt.Sprintf(`Argument "%v" has invalid value %v.%v`,
+			name, printer.Print(valueAST), "\n"+strings.Join(messages, "\n")),
+		invalidValueNodes(ttype, valueAST),
+		"",
+		nil,
+		[]int{},
+		nil,
+	)
+}
+
+// isExplicitNull returns true if the value is a null literal or a variable
+// that was provided with a null value (as opposed to being omitted).
+func isExplicitNull(valueAST ast.Value, variables map[string]interface{}) bool {
+	switch v := valueAST.(type) {
+	case *ast.NullValue:
+		return true
+	case *ast.Variable:
+		if v.Name == nil {
+			return false
+		}
+		value, ok := variables[v.Name.Value]
+		return ok && value == nil
+	}
+	return false
+}
+
+// deprecatedInputWarningsExtension is the result extension that uses of
+// deprecated enum values are reported in.
+const deprecatedInputWarningsExtension = "warnings"
+
+// deprecatedEnumInput is the use of a deprecated value of an enum that reports
+// deprecated input, located at node.
+type deprecatedEnumInput struct {
+	enum  *Enum
+	value *EnumValueDefinition
+	node  ast.Node
+}
+
+func (d deprecatedEnumInput) error(typ gqlerrors.ErrorType) error {
+	return gqlerrors.NewError(
+		typ,
+		fmt.Sprintf(`The enum value "%s.%s" is deprecated. %s`, d.enum.Name(), d.value.Name, d.value.DeprecationReason),
+		[]ast.Node{d.node},
+		"",
+		nil,
+		[]int{},
+		nil,
+	)
+}
+
+// reportDeprecatedInputs returns an error if any of the inputs is of an enum
+// with StrictDeprecatedInput set. Otherwise the inputs are added as warnings to
+// the extensions of the request executed with the context, once per location.
+func reportDeprecatedInputs(ctx context.Context, inputs []deprecatedEnumInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+	for _, input := range inputs {
+		if input.enum.enumConfig.StrictDeprecatedInput {
+			return input.error(gqlerrors.ErrorTypeInvalidInput)
+		}
+	}
+	ext := ExtensionsFromContext(ctx)
+	for _, input := range inputs {
+		warning := gqlerrors.FormatError(input.error(""))
+		ext.Update(deprecatedInputWarningsExtension, func(value interface{}) interface{} {
+			warnings, _ := value.([]gqlerrors.FormattedError)
+			for _, w := range warnings {
+				if w.Message == warning.Message && reflect.DeepEqual(w.Locations, warning.Locations) {
+					return warnings
+				}
+			}
+			return append(warnings, warning)
+		})
+	}
+	return nil
+}
+
+// deprecatedVariableInputs returns the deprecated enum values used in the
+// value of a variable, located at its definition.
+func deprecatedVariableInputs(ttype Input, value interface{}, definitionAST *ast.VariableDefinition) []deprecatedEnumInput {
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return deprecatedVariableInputs(ttype.OfType, value, definitionAST)
+	case *List:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice {
+			return deprecatedVariableInputs(ttype.OfType, value, definitionAST)
+		}
+		var inputs []deprecatedEnumInput
+		for i := 0; i < v.Len(); i++ {
+			inputs = append(inputs, deprecatedVariableInputs(ttype.OfType, v.Index(i).Interface(), definitionAST)...)
+		}
+		return inputs
+	case *InputObject:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := ttype.Fields()
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var inputs []deprecatedEnumInput
+		for _, name := range names {
+			if field, ok := fields[name]; ok {
+				inputs = append(inputs, deprecatedVariableInputs(field.Type, obj[name], definitionAST)...)
+			}
+		}
+		return inputs
+	case *Enum:
+		if name, ok := value.(string); ok {
+			if def := ttype.deprecatedInput(name); def != nil {
+				return []deprecatedEnumInput{{enum: ttype, value: def, node: definitionAST}}
+			}
+		}
+	}
+	return nil
+}
+
+// deprecatedLiteralInputs returns the deprecated enum values used in a
+// literal. The values of variables are checked when they're coerced.
+func deprecatedLiteralInputs(ttype Input, valueAST ast.Value) []deprecatedEnumInput {
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return deprecatedLiteralInputs(ttype.OfType, valueAST)
+	case *List:
+		list, ok := valueAST.(*ast.ListValue)
+		if !ok {
+			return deprecatedLiteralInputs(ttype.OfType, valueAST)
+		}
+		var inputs []deprecatedEnumInput
+		for _, item := range list.Values {
+			inputs = append(inputs, deprecatedLiteralInputs(ttype.OfType, item)...)
+		}
+		return inputs
+	case *InputObject:
+		obj, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			return nil
+		}
+		fields := ttype.Fields()
+		var inputs []deprecatedEnumInput
+		for _, f := range obj.Fields {
+			if f == nil || f.Name == nil {
+				continue
+			}
+			if field, ok := fields[f.Name.Value]; ok {
+				inputs = append(inputs, deprecatedLiteralInputs(field.Type, f.Value)...)
+			}
+		}
+		return inputs
+	case *Enum:
+		if enumValue, ok := valueAST.(*ast.EnumValue); ok {
+			if def := ttype.deprecatedInput(enumValue.Value); def != nil {
+				return []deprecatedEnumInput{{enum: ttype, value: def, node: enumValue}}
+			}
+		}
+	}
+	return nil
+}
+
 // Given a variable definition, and any value of input, return a value which
 // adheres to the variable definition, or throw an error.
-func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}) (interface{}, error) {
+func getVariableValue(ctx context.Context, schema Schema, definitionAST *ast.VariableDefinition, input interface{}, provided, lenientBools bool) (interface{}, error) {
 	ttype, err := typeFromAST(schema, definitionAST.Type)
 	if err != nil {
 		return nil, err
 	}
 	variable := definitionAST.Variable
 
+	if raw, ok := input.(json.RawMessage); ok {
+		input, err = decodeRawVariable(raw)
+		if err != nil {
+			return "", gqlerrors.NewError(
+				gqlerrors.ErrorTypeInvalidInput,
+				fmt.Sprintf(`Variable "$%v" got invalid JSON value: %v`, variable.Name.Value, err),
+				[]ast.Node{definitionAST},
+				"",
+				nil,
+				[]int{},
+				err,
+			)
+		}
+	}
+
 	if ttype == nil || !IsInputType(ttype) {
 		return "", gqlerrors.NewError(
 			gqlerrors.ErrorTypeInvalidInput,
@@ -81,9 +376,15 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		)
 	}
 
+	if lenientBools {
+		input = lenientBoolValue(ttype, input)
+	}
+
 	isValid, messages := isValidInputValue(input, ttype)
 	if isValid {
-		if isNullish(input) {
+		// Only omitted variables get the default, as an explicit null
+		// overrides it.
+		if isNullish(input) && !provided {
 			defaultValue := definitionAST.DefaultValue
 			if defaultValue != nil {
 				variables := map[string]interface{}{}
@@ -91,6 +392,9 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 				return val, nil
 			}
 		}
+		if err := reportDeprecatedInputs(ctx, deprecatedVariableInputs(ttype, input, definitionAST)); err != nil {
+			return "", err
+		}
 		return coerceValue(ttype, input), nil
 	}
 	if isNullish(input) {
@@ -127,6 +431,93 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 	)
 }
 
+// lenientBoolValue converts the 0/1 and "true"/"false" values some legacy clients
+// send for Boolean inputs into bools. All other values are returned unchanged.
+func lenientBoolValue(ttype Type, value interface{}) interface{} {
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return lenientBoolValue(ttype.OfType, value)
+	case *List:
+		list, ok := value.([]interface{})
+		if !ok {
+			return lenientBoolValue(ttype.OfType, value)
+		}
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = lenientBoolValue(ttype.OfType, item)
+		}
+		return items
+	case *InputObject:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		fields := ttype.Fields()
+		values := make(map[string]interface{}, len(obj))
+		for name, v := range obj {
+			if field, ok := fields[name]; ok {
+				v = lenientBoolValue(field.Type, v)
+			}
+			values[name] = v
+		}
+		return values
+	case *Scalar:
+		if ttype != Boolean {
+			return value
+		}
+		switch v := value.(type) {
+		case string:
+			switch v {
+			case "true":
+				return true
+			case "false":
+				return false
+			}
+		case json.Number:
+			switch v {
+			case "1":
+				return true
+			case "0":
+				return false
+			}
+		case float64:
+			switch v {
+			case 1:
+				return true
+			case 0:
+				return false
+			}
+		case int:
+			switch v {
+			case 1:
+				return true
+			case 0:
+				return false
+			}
+		}
+	}
+	return value
+}
+
+// decodeRawVariable decodes a raw JSON variable value, keeping numbers as
+// json.Number so that they're coerced according to the declared input type.
+// The raw value must hold a single JSON value.
+func decodeRawVariable(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	return v, nil
+}
+
 // Given a type and any value, return a runtime value coerced to match the type.
 func coerceValue(ttype Input, value interface{}) interface{} {
 	if ttype, ok := ttype.(*NonNull); ok {
@@ -158,9 +549,14 @@ func coerceValue(ttype Input, value interface{}) interface{} {
 
 		obj := map[string]interface{}{}
 		for fieldName, field := range ttype.Fields() {
-			value, _ := valueMap[fieldName]
+			value, provided := valueMap[fieldName]
 			fieldValue := coerceValue(field.Type, value)
 			if isNullish(fieldValue) {
+				// An explicit null overrides the default.
+				if field.DefaultValue != nil && provided && value == nil {
+					obj[fieldName] = nil
+					continue
+				}
 				fieldValue = field.DefaultValue
 			}
 			if !isNullish(fieldValue) {
@@ -207,8 +603,11 @@ func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 		if inputTypeAST.Name != nil {
 			nameValue = inputTypeAST.Name.Value
 		}
-		ttype := schema.Type(nameValue)
-		return ttype, nil
+		// Types hidden from clients can't be named in a document.
+		if !schema.typeVisible(nameValue) {
+			return nil, nil
+		}
+		return schema.LoadType(nameValue)
 	default:
 		if _, ok := inputTypeAST.(*ast.Named); !ok {
 			return nil, gqlerrors.NewFormattedError("Must be a named type.")
@@ -282,16 +681,28 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 		// Ensure every provided field is defined.
 		for _, fieldName := range valueMapFieldNames {
 			if _, ok := fields[fieldName]; !ok {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldName))
+				messagesReduce = append(messagesReduce, unknownInputFieldMessage(fieldName, ttype))
 			}
 		}
-		// Ensure every defined field is valid.
+		// Ensure every defined field is valid. Missing fields with a default
+		// value aren't required.
 		for _, fieldName := range fieldNames {
+			if _, ok := valueMap[fieldName]; !ok && fields[fieldName].DefaultValue != nil {
+				continue
+			}
 			_, messages := isValidInputValue(valueMap[fieldName], fields[fieldName].Type)
 			for _, message := range messages {
 				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": %v`, fieldName, message))
 			}
 		}
+		// Exactly one field of a @oneOf input object must be non-null.
+		if ttype.IsOneOf() {
+			if len(valueMapFieldNames) != 1 {
+				messagesReduce = append(messagesReduce, fmt.Sprintf(`Exactly one key must be specified for OneOf type "%v".`, ttype.Name()))
+			} else if fieldName := valueMapFieldNames[0]; isNullish(valueMap[fieldName]) {
+				messagesReduce = append(messagesReduce, fmt.Sprintf(`Field "%v" must be non-null.`, fieldName))
+			}
+		}
 
 		return len(messagesReduce) == 0, messagesReduce
 	}
@@ -324,8 +735,8 @@ func isNullish(value interface{}) bool {
 	case float64:
 		return math.IsNaN(v)
 	}
-	// The interface{} can hide an underlying nil ptr
-	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr {
+	// The interface{} can hide an underlying nil ptr or chan
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr || v.Kind() == reflect.Chan {
 		return v.IsNil()
 	}
 	return false
@@ -349,6 +760,47 @@ func isEmptyValue(v reflect.Value) bool {
 	return false
 }
 
+// ValueFromAST converts a literal to a value of the input type, substituting the
+// values of the variables it refers to. Variable values are expected to have
+// already been coerced to their types, as Execute does. It returns an error if
+// the literal isn't valid for the type.
+func ValueFromAST(valueAST ast.Value, ttype Type, variables map[string]interface{}) (interface{}, error) {
+	inputType, ok := ttype.(Input)
+	if !ok || !IsInputType(ttype) {
+		return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Expected an input type but got: %v.`, ttype))
+	}
+	if isValid, messages := isValidLiteralValue(inputType, valueAST); !isValid {
+		message := strings.Join(messages, "\n")
+		var nodes []ast.Node
+		if _, ok := valueAST.(*ast.NullValue); valueAST != nil && !ok {
+			message = fmt.Sprintf("Invalid value %v for type \"%v\".\n%v", printer.Print(valueAST), ttype, message)
+			nodes = invalidValueNodes(inputType, valueAST)
+		}
+		return nil, gqlerrors.NewError(
+			gqlerrors.ErrorTypeInvalidInput,
+			message,
+			nodes,
+			"",
+			nil,
+			[]int{},
+			nil,
+		)
+	}
+	value := valueFromAST(valueAST, inputType, variables)
+	if _, ok := ttype.(*NonNull); ok && isNullish(value) {
+		return nil, gqlerrors.NewError(
+			gqlerrors.ErrorTypeInvalidInput,
+			fmt.Sprintf(`Expected "%v", found null.`, ttype),
+			[]ast.Node{valueAST},
+			"",
+			nil,
+			[]int{},
+			nil,
+		)
+	}
+	return value, nil
+}
+
 /**
  * Produces a value given a GraphQL Value AST.
  *
@@ -365,6 +817,10 @@ func isEmptyValue(v reflect.Value) bool {
  *
  */
 func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interface{}) interface{} {
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		valueAST = nil
+	}
+
 	if ttype, ok := ttype.(*NonNull); ok {
 		val := valueFromAST(valueAST, ttype.OfType, variables)
 		return val
@@ -422,12 +878,17 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 		}
 		obj := make(map[string]interface{})
 		for fieldName, field := range ttype.Fields() {
-			fieldAST, ok := fieldASTs[fieldName]
-			if !ok || fieldAST == nil {
-				continue
+			var fieldValue interface{}
+			fieldAST := fieldASTs[fieldName]
+			if fieldAST != nil {
+				fieldValue = valueFromAST(fieldAST.Value, field.Type, variables)
 			}
-			fieldValue := valueFromAST(fieldAST.Value, field.Type, variables)
 			if isNullish(fieldValue) {
+				// An explicit null overrides the default.
+				if field.DefaultValue != nil && fieldAST != nil && isExplicitNull(fieldAST.Value, variables) {
+					obj[fieldName] = nil
+					continue
+				}
 				fieldValue = field.DefaultValue
 			}
 			if !isNullish(fieldValue) {
@@ -2,7 +2,9 @@ package graphql_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sprucehealth/graphql"
@@ -234,6 +236,15 @@ func TestVariables_ObjectsAndNullability_UsingInlineStructs_DoesNotUseIncorrectV
 		Data: map[string]interface{}{
 			"fieldWithObjectInput": nil,
 		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Type: gqlerrors.ErrorTypeInvalidInput,
+				Message: `Argument "input" has invalid value ["foo", "bar", "baz"].` +
+					"\nExpected \"TestInputObject\", found not an object.",
+				Locations: []location.SourceLocation{{Line: 3, Column: 39}},
+				Path:      []interface{}{"fieldWithObjectInput"},
+			},
+		},
 	}
 	// parse query
 	ast := testutil.TestParse(t, doc)
@@ -244,9 +255,6 @@ func TestVariables_ObjectsAndNullability_UsingInlineStructs_DoesNotUseIncorrectV
 		AST:    ast,
 	}
 	result := testutil.TestExecute(t, ep)
-	if len(result.Errors) > 0 {
-		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
-	}
 	if !reflect.DeepEqual(expected, result) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
@@ -254,12 +262,12 @@ func TestVariables_ObjectsAndNullability_UsingInlineStructs_DoesNotUseIncorrectV
 func TestVariables_ObjectsAndNullability_UsingInlineStructs_ProperlyRunsParseLiteralOnComplexScalarTypes(t *testing.T) {
 	doc := `
         {
-          fieldWithObjectInput(input: {a: "foo", d: "SerializedValue"})
+          fieldWithObjectInput(input: {a: "foo", c: "baz", d: "SerializedValue"})
         }
 	`
 	expected := &graphql.Result{
 		Data: map[string]interface{}{
-			"fieldWithObjectInput": `{"a":"foo","d":"DeserializedValue"}`,
+			"fieldWithObjectInput": `{"a":"foo","c":"baz","d":"DeserializedValue"}`,
 		},
 	}
 	// parse query
@@ -279,6 +287,121 @@ func TestVariables_ObjectsAndNullability_UsingInlineStructs_ProperlyRunsParseLit
 	}
 }
 
+func TestVariables_ObjectsAndNullability_UsingInlineStructs_ErrorsOnAdditionOfUnknownInputField(t *testing.T) {
+	doc := `
+        {
+          fieldWithObjectInput(input: {a: "foo", c: "baz", extra: "dog"})
+        }
+	`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"fieldWithObjectInput": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Type: gqlerrors.ErrorTypeInvalidInput,
+				Message: `Argument "input" has invalid value {a: "foo", c: "baz", extra: "dog"}.` +
+					"\nIn field \"extra\": Unknown field on input type \"TestInputObject\".",
+				Locations: []location.SourceLocation{{Line: 3, Column: 60}},
+				Path:      []interface{}{"fieldWithObjectInput"},
+			},
+		},
+	}
+	// parse query
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_ObjectsAndNullability_UsingInlineStructs_ErrorsOnOmissionOfRequiredInputField(t *testing.T) {
+	doc := `
+        {
+          fieldWithObjectInput(input: {a: "foo", b: ["bar"]})
+        }
+	`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"fieldWithObjectInput": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Type: gqlerrors.ErrorTypeInvalidInput,
+				Message: `Argument "input" has invalid value {a: "foo", b: ["bar"]}.` +
+					"\nIn field \"c\": Expected \"String!\", found null.",
+				Locations: []location.SourceLocation{{Line: 3, Column: 39}},
+				Path:      []interface{}{"fieldWithObjectInput"},
+			},
+		},
+	}
+	// parse query
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_ObjectsAndNullability_RequiredInputFieldsWithDefaultsMayBeOmitted(t *testing.T) {
+	inputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PageInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"first": &graphql.InputObjectFieldConfig{
+				Type:         graphql.NewNonNull(graphql.Int),
+				DefaultValue: 10,
+			},
+			"after": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"first": &graphql.Field{
+					Type: graphql.Int,
+					Args: graphql.FieldConfigArgument{
+						"page": &graphql.ArgumentConfig{Type: inputType},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["page"].(map[string]interface{})["first"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"variable": 10,
+			"literal":  10,
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($page: PageInput) { variable: first(page: $page) literal: first(page: {after: "x"}) }`,
+		VariableValues: map[string]interface{}{"page": map[string]interface{}{"after": "x"}},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
 func testVariables_ObjectsAndNullability_UsingVariables_GetAST(t *testing.T) *ast.Document {
 	doc := `
         query q($input: TestInputObject) {
@@ -581,7 +704,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnAdditionOfUnknow
 			{
 				Type: gqlerrors.ErrorTypeInvalidInput,
 				Message: `Variable "$input" got invalid value {"a":"foo","b":"bar","c":"baz","extra":"dog"}.` +
-					"\nIn field \"extra\": Unknown field.",
+					"\nIn field \"extra\": Unknown field on input type \"TestInputObject\".",
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,
@@ -1489,6 +1612,78 @@ func TestVariables_UsesArgumentDefaultValues_WhenNullableVariableProvided(t *tes
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_ResolversReceiveArgumentDefaultValues(t *testing.T) {
+	colorEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: "red"},
+			"GREEN": &graphql.EnumValueConfig{Value: "green"},
+		},
+	})
+	pageInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Page",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"first": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"after": &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: "start"},
+		},
+	})
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"name":  &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "anonymous"},
+			"count": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+			"color": &graphql.ArgumentConfig{Type: colorEnum, DefaultValue: "red"},
+			"page":  &graphql.ArgumentConfig{Type: pageInput, DefaultValue: map[string]interface{}{"first": 5}},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			b, err := json.Marshal(p.Args)
+			return string(b), err
+		},
+	})
+
+	for _, tc := range []struct {
+		query    string
+		vars     map[string]interface{}
+		expected string
+	}{
+		{
+			query:    `{ test }`,
+			expected: `{"color":"red","count":10,"name":"anonymous","page":{"first":5}}`,
+		},
+		{
+			query:    `{ test(name: "bob", count: 1, color: GREEN, page: {first: 2}) }`,
+			expected: `{"color":"green","count":1,"name":"bob","page":{"after":"start","first":2}}`,
+		},
+		{
+			query:    `query q($name: String, $count: Int) { test(name: $name, count: $count) }`,
+			vars:     map[string]interface{}{"name": nil},
+			expected: `{"color":"red","count":10,"name":null,"page":{"first":5}}`,
+		},
+		{
+			query:    `{ test(name: null, page: {first: 1, after: null}) }`,
+			expected: `{"color":"red","count":10,"name":null,"page":{"after":null,"first":1}}`,
+		},
+		{
+			query:    `query q($page: Page) { test(page: $page) }`,
+			vars:     map[string]interface{}{"page": map[string]interface{}{"first": 1, "after": nil}},
+			expected: `{"color":"red","count":10,"name":"anonymous","page":{"after":null,"first":1}}`,
+		},
+	} {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  tc.query,
+			VariableValues: tc.vars,
+		})
+		expected := &graphql.Result{
+			Data: map[string]interface{}{"test": tc.expected},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result for %s, Diff: %v", tc.query, testutil.Diff(expected, result))
+		}
+	}
+}
+
 func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(t *testing.T) {
 	doc := `
 	{
@@ -1515,3 +1710,330 @@ func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_RawVariableValues_CoercesNumbersByDeclaredType(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"numbers": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"i":    &graphql.ArgumentConfig{Type: graphql.Int},
+						"f":    &graphql.ArgumentConfig{Type: graphql.Float},
+						"list": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.Int)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return fmt.Sprintf("%T:%v %T:%v %v", p.Args["i"], p.Args["i"], p.Args["f"], p.Args["f"], p.Args["list"]), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `query ($i: Int, $f: Float, $list: [Int]) { numbers(i: $i, f: $f, list: $list) }`
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		RawVariableValues: map[string]json.RawMessage{
+			"i":      json.RawMessage(`3`),
+			"f":      json.RawMessage(`3`),
+			"list":   json.RawMessage(`[1, 2]`),
+			"unused": json.RawMessage(`{not json`),
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"numbers": "int:3 float64:3 [1 2]",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		RawVariableValues: map[string]json.RawMessage{
+			"i": json.RawMessage(`1.5`),
+		},
+	})
+	if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0].Message, `Variable "$i" got invalid value 1.5.`) {
+		t.Fatalf("Expected invalid Int error, got %v", result.Errors)
+	}
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		RawVariableValues: map[string]json.RawMessage{
+			"i": json.RawMessage(`3 4`),
+		},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Variable "$i" got invalid JSON value: invalid character after top-level value` {
+		t.Fatalf("Expected invalid JSON error, got %v", result.Errors)
+	}
+}
+
+func TestVariables_PreCoercedVariablesSkipCoercion(t *testing.T) {
+	parseValueCalls := 0
+	pointScalar := graphql.NewScalar(graphql.ScalarConfig{
+		Name: "Point",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: func(value interface{}) interface{} {
+			parseValueCalls++
+			return value
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"describe": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"point": &graphql.ArgumentConfig{Type: pointScalar},
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return fmt.Sprintf("%v %v", p.Args["point"], p.Args["limit"]), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `query ($point: Point!, $limit: Int = 10) { describe(point: $point, limit: $limit) }`
+	type point struct{ X, Y int }
+
+	result := graphql.Do(graphql.Params{
+		Schema:                schema,
+		RequestString:         query,
+		VariableValues:        map[string]interface{}{"point": point{1, 2}},
+		VariableValuesCoerced: true,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"describe": "{1 2} 10",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if parseValueCalls != 0 {
+		t.Fatalf("Expected ParseValue not to be called, got %d calls", parseValueCalls)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"point": point{1, 2}},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if parseValueCalls == 0 {
+		t.Fatal("Expected ParseValue to be called without pre-coerced variables")
+	}
+
+	// A variable that's explicitly null doesn't get its default either way.
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"describe": "{1 2} <nil>",
+		},
+	}
+	for _, coerced := range []bool{true, false} {
+		result = graphql.Do(graphql.Params{
+			Schema:                schema,
+			RequestString:         query,
+			VariableValues:        map[string]interface{}{"point": point{1, 2}, "limit": nil},
+			VariableValuesCoerced: coerced,
+		})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result with coerced %v, Diff: %v", coerced, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestVariables_LenientBooleans(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"flags": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Boolean))},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return fmt.Sprintf("%v", p.Args["flags"]), nil
+		},
+	})
+	query := `query q($flags: [Boolean!]) { test(flags: $flags) }`
+	vars := map[string]interface{}{"flags": []interface{}{1, 0, "true", "false", true}}
+
+	result := graphql.Do(graphql.Params{
+		Schema:          schema,
+		RequestString:   query,
+		VariableValues:  vars,
+		LenientBooleans: true,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"test": "[true false true false true]",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"flags": []interface{}{1}},
+	})
+	if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0].Message, `Variable "$flags" got invalid value [1].`) {
+		t.Fatalf("Expected strict mode to reject 1, got %+v", result)
+	}
+}
+
+func parseArgumentLiteral(t *testing.T, literal string) ast.Value {
+	doc := testutil.TestParse(t, fmt.Sprintf(`{ f(arg: %s) }`, literal))
+	field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	return field.Arguments[0].Value
+}
+
+func TestVariables_ValueFromAST(t *testing.T) {
+	pointType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Point",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"x":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"y":    &graphql.InputObjectFieldConfig{Type: graphql.Int, DefaultValue: 0},
+			"tags": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+		},
+	})
+	shapeType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Shape",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"center": &graphql.InputObjectFieldConfig{Type: pointType},
+			"points": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(pointType))},
+		},
+	})
+	variables := map[string]interface{}{
+		"name": "triangle",
+		"x":    3,
+		"tags": []interface{}{"a", "b"},
+	}
+
+	tests := []struct {
+		literal  string
+		ttype    graphql.Type
+		expected interface{}
+	}{
+		{`1`, graphql.Int, 1},
+		{`$x`, graphql.NewNonNull(graphql.Int), 3},
+		{`$missing`, graphql.Int, nil},
+		{`"a"`, graphql.NewList(graphql.String), []interface{}{"a"}},
+		{`{x: 1}`, pointType, map[string]interface{}{"x": 1, "y": 0}},
+		{
+			`{name: $name, center: {x: $x, y: 2, tags: $tags}, points: [{x: 1}, {x: $x, tags: "c"}]}`,
+			shapeType,
+			map[string]interface{}{
+				"name":   "triangle",
+				"center": map[string]interface{}{"x": 3, "y": 2, "tags": []interface{}{"a", "b"}},
+				"points": []interface{}{
+					map[string]interface{}{"x": 1, "y": 0},
+					map[string]interface{}{"x": 3, "y": 0, "tags": []interface{}{"c"}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		value, err := graphql.ValueFromAST(parseArgumentLiteral(t, test.literal), test.ttype, variables)
+		if err != nil {
+			t.Fatalf("Unexpected error converting %s: %v", test.literal, err)
+		}
+		if !reflect.DeepEqual(test.expected, value) {
+			t.Fatalf("Unexpected value converting %s, Diff: %v", test.literal, testutil.Diff(test.expected, value))
+		}
+	}
+
+	errorTests := []struct {
+		literal string
+		ttype   graphql.Type
+		message string
+	}{
+		{`"1"`, graphql.Int, "Invalid value \"1\" for type \"Int\".\nExpected type \"Int\", found \"1\"."},
+		{`{y: 1}`, pointType, "Invalid value {y: 1} for type \"Point\".\nIn field \"x\": Expected \"Int!\", found null."},
+		{`{name: "a", center: {x: 1, z: 2}}`, shapeType, "Invalid value {name: \"a\", center: {x: 1, z: 2}} for type \"Shape\".\nIn field \"center\": In field \"z\": Unknown field on input type \"Point\". Did you mean \"x\" or \"y\"?"},
+		{`$missing`, graphql.NewNonNull(graphql.Int), `Expected "Int!", found null.`},
+	}
+	for _, test := range errorTests {
+		_, err := graphql.ValueFromAST(parseArgumentLiteral(t, test.literal), test.ttype, variables)
+		if err == nil || err.Error() != test.message {
+			t.Fatalf("Expected error %q converting %s, got %v", test.message, test.literal, err)
+		}
+	}
+	if _, err := graphql.ValueFromAST(nil, graphql.NewNonNull(graphql.Int), nil); err == nil || err.Error() != `Expected "Int!", found null.` {
+		t.Fatalf("Expected a missing non-null value to be rejected, got %v", err)
+	}
+}
+
+func TestVariables_OneOfInputObjects_RequireExactlyOneNonNullField(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+type Query {
+  user(by: UserBy): String
+}
+
+input UserBy @oneOf {
+  id: ID
+  email: String
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := `query Q($by: UserBy) { user(by: $by) }`
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"by": map[string]interface{}{"id": "1"}},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+
+	tests := []struct {
+		by      map[string]interface{}
+		message string
+	}{
+		{
+			map[string]interface{}{},
+			"Variable \"$by\" got invalid value {}.\nExactly one key must be specified for OneOf type \"UserBy\".",
+		},
+		{
+			map[string]interface{}{"id": "1", "email": "a@example.com"},
+			"Variable \"$by\" got invalid value {\"email\":\"a@example.com\",\"id\":\"1\"}.\nExactly one key must be specified for OneOf type \"UserBy\".",
+		},
+		{
+			map[string]interface{}{"id": nil},
+			"Variable \"$by\" got invalid value {\"id\":null}.\nField \"id\" must be non-null.",
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: map[string]interface{}{"by": test.by},
+		})
+		if len(result.Errors) != 1 || result.Errors[0].Message != test.message {
+			t.Fatalf("Expected error %q for %v, got %v", test.message, test.by, result.Errors)
+		}
+	}
+}